@@ -1,8 +1,11 @@
 package data
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"time"
@@ -54,3 +57,46 @@ func init() {
 	base = testVector().Clone()
 	rand.Seed(time.Now().UnixNano())
 }
+
+// A custom Item holding a *url.URL, transmitting as a registered "url" type.
+type urlItem struct {
+	Item
+	u *url.URL
+}
+
+func newURLItem(k string, u *url.URL) *urlItem {
+	i := &urlItem{KeyedItem(k), u}
+	i.Provide(u.String())
+	return i
+}
+
+func (i *urlItem) TypeName() string {
+	return "url"
+}
+
+func (i *urlItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&Mtem{Key: i.Key(), Value: i.u.String(), Type: i.TypeName()})
+}
+
+func (i *urlItem) MarshalYAML() (interface{}, error) {
+	return &Mtem{Key: i.Key(), Value: i.u.String(), Type: i.TypeName()}, nil
+}
+
+func (i *urlItem) Clone() Item {
+	return newURLItem(i.Key(), i.u)
+}
+
+func init() {
+	RegisterTypedItem[net.IP]("ip")
+	RegisterItemType("url", func(k string, raw interface{}) (Item, error) {
+		s, ok := raw.(string)
+		if !ok {
+			return nil, ConversionError(raw, "url")
+		}
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		return newURLItem(k, u), nil
+	})
+}
@@ -0,0 +1,275 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/Laughs-In-Flowers/xrr"
+)
+
+// Patch operations, named as in RFC 6902.
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+)
+
+// A single difference between two Vector. Path holds the key of the changed
+// Item, preceded by the keys of any VectorItem it is nested within. Old is nil
+// for an added Item, New is nil for a removed Item.
+type Change struct {
+	Op   string
+	Path []string
+	Old  Item
+	New  Item
+}
+
+// An ordered list of Change, transmitting as an RFC 6902 JSON Patch.
+type Patch []*Change
+
+// Returns a Patch that changes Vector a into Vector b, recursing into
+// VectorItem held by both. The identifying keys "vector.tag" and "vector.id"
+// are skipped, as by MergeWith.
+func Diff(a, b *Vector) Patch {
+	return diff(nil, a.List(), b.List())
+}
+
+func itemMap(l []Item) map[string]Item {
+	ret := make(map[string]Item)
+	for _, i := range l {
+		if !identifying(i.Key()) {
+			ret[i.Key()] = i
+		}
+	}
+	return ret
+}
+
+func diff(path []string, a, b []Item) Patch {
+	am, bm := itemMap(a), itemMap(b)
+	var keys []string
+	for k := range am {
+		keys = append(keys, k)
+	}
+	for k := range bm {
+		if _, ok := am[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var ret Patch
+	for _, k := range keys {
+		ai, inA := am[k]
+		bi, inB := bm[k]
+		p := append(append([]string{}, path...), k)
+		switch {
+		case !inA:
+			ret = append(ret, &Change{PatchAdd, p, nil, bi})
+		case !inB:
+			ret = append(ret, &Change{PatchRemove, p, ai, nil})
+		case bytes.Equal(ai.Value(), bi.Value()):
+		default:
+			av, bv := heldVector(ai), heldVector(bi)
+			if av != nil && bv != nil {
+				ret = append(ret, diff(p, av.List(), bv.List())...)
+				continue
+			}
+			ret = append(ret, &Change{PatchReplace, p, ai, bi})
+		}
+	}
+	return ret
+}
+
+var (
+	PatchOpError     = xrr.Xrror("unsupported patch operation %s").Out
+	PatchPathError   = xrr.Xrror("patch path %s is invalid").Out
+	PatchTargetError = xrr.Xrror("patch %s target %s does not exist").Out
+)
+
+// Applies the Patch to this Vector as a single Tx. No Change is applied if any
+// Change fails.
+func (v *Vector) Apply(p Patch) error {
+	tx := v.Begin()
+	for _, c := range p {
+		if err := apply(tx, c, c.Path); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+type applier interface {
+	Get(string) Item
+	Set(...Item)
+	Delete(...string) int
+}
+
+func apply(a applier, c *Change, path []string) error {
+	if len(path) == 0 {
+		return PatchPathError(c.pointer())
+	}
+	k := path[0]
+	exists := a.Get(k) != nil
+
+	if len(path) > 1 {
+		nv := copyVector(a.Get(k))
+		if nv == nil {
+			return PatchTargetError(c.Op, c.pointer())
+		}
+		if err := apply(nv, c, path[1:]); err != nil {
+			return err
+		}
+		a.Set(NewVectorItem(k, nv))
+		return nil
+	}
+
+	switch c.Op {
+	case PatchAdd, PatchReplace:
+		if c.New == nil {
+			return PatchPathError(c.pointer())
+		}
+		if c.Op == PatchReplace && !exists {
+			return PatchTargetError(c.Op, c.pointer())
+		}
+		ni := c.New.Clone()
+		ni.NewKey(k)
+		a.Set(ni)
+	case PatchRemove:
+		if !exists {
+			return PatchTargetError(c.Op, c.pointer())
+		}
+		a.Delete(k)
+	default:
+		return PatchOpError(c.Op)
+	}
+	return nil
+}
+
+var pointerEscape = strings.NewReplacer("~", "~0", "/", "~1")
+
+var pointerUnescape = strings.NewReplacer("~1", "/", "~0", "~")
+
+// Returns the Change path as an RFC 6901 JSON Pointer.
+func (c *Change) pointer() string {
+	var b strings.Builder
+	for _, k := range c.Path {
+		b.WriteString("/")
+		b.WriteString(pointerEscape.Replace(k))
+	}
+	return b.String()
+}
+
+func fromPointer(p string) ([]string, error) {
+	if !strings.HasPrefix(p, "/") {
+		return nil, PatchPathError(p)
+	}
+	var ret []string
+	for _, k := range strings.Split(p[1:], "/") {
+		ret = append(ret, pointerUnescape.Replace(k))
+	}
+	return ret, nil
+}
+
+// An intermediary transmission type for a Change.
+type PatchOp struct {
+	Op      string      `json:"op" yaml:"op"`
+	Path    string      `json:"path" yaml:"path"`
+	Value   interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+	Old     interface{} `json:"old,omitempty" yaml:"old,omitempty"`
+	Type    string      `json:"type,omitempty" yaml:"type,omitempty"`
+	OldType string      `json:"oldType,omitempty" yaml:"oldType,omitempty"`
+}
+
+func (c *Change) toPatchOp() *PatchOp {
+	ret := &PatchOp{Op: c.Op, Path: c.pointer()}
+	if c.New != nil {
+		ret.Value = c.New.Provided()
+		ret.Type = itemTypeName(c.New)
+	}
+	if c.Old != nil {
+		ret.Old = c.Old.Provided()
+		ret.OldType = itemTypeName(c.Old)
+	}
+	return ret
+}
+
+func (c *Change) fromPatchOp(o *PatchOp) error {
+	p, err := fromPointer(o.Path)
+	if err != nil {
+		return err
+	}
+	k := p[len(p)-1]
+	c.Op, c.Path, c.Old, c.New = o.Op, p, nil, nil
+	if o.Value != nil {
+		if c.New, err = fromMtem(&Mtem{Key: k, Value: o.Value, Type: o.Type}); err != nil {
+			return err
+		}
+	}
+	if o.Old != nil {
+		if c.Old, err = fromMtem(&Mtem{Key: k, Value: o.Old, Type: o.OldType}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// json.Marshaler
+func (c *Change) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.toPatchOp())
+}
+
+// json.Unmarshaler
+func (c *Change) UnmarshalJSON(b []byte) error {
+	var o PatchOp
+	if err := decodeJSON(b, &o); err != nil {
+		return err
+	}
+	return c.fromPatchOp(&o)
+}
+
+// yaml.Marshaler
+func (c *Change) MarshalYAML() (interface{}, error) {
+	return c.toPatchOp(), nil
+}
+
+// yaml.Unmarshaler
+func (c *Change) UnmarshalYAML(u func(interface{}) error) error {
+	var o PatchOp
+	if err := u(&o); err != nil {
+		return err
+	}
+	return c.fromPatchOp(&o)
+}
+
+// json.Marshaler
+func (p Patch) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]*Change(p))
+}
+
+// json.Unmarshaler
+func (p *Patch) UnmarshalJSON(b []byte) error {
+	var c []*Change
+	if err := json.Unmarshal(b, &c); err != nil {
+		return err
+	}
+	*p = c
+	return nil
+}
+
+// yaml.Marshaler
+func (p Patch) MarshalYAML() (interface{}, error) {
+	return []*Change(p), nil
+}
+
+// yaml.Unmarshaler
+func (p *Patch) UnmarshalYAML(u func(interface{}) error) error {
+	var c []*Change
+	if err := u(&c); err != nil {
+		return err
+	}
+	*p = c
+	return nil
+}
@@ -0,0 +1,121 @@
+package data
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+func diffVectors() (*Vector, *Vector) {
+	a := New("DIFF")
+	a.SetString("a.string", "one")
+	a.SetInt("a.int", 1)
+	a.SetBool("a.removed", true)
+	na := New("nested")
+	na.SetString("n/1", "ONE")
+	a.SetVector("a.vector", na)
+
+	b := New("DIFF")
+	b.SetString("a.string", "two")
+	b.SetInt("a.int", 1)
+	b.SetStrings("a.added", "x", "y")
+	nb := New("nested")
+	nb.SetString("n/1", "TWO")
+	b.SetVector("a.vector", nb)
+	return a, b
+}
+
+func TestDiff(t *testing.T) {
+	a, b := diffVectors()
+	p := Diff(a, b)
+	expect := map[string]string{
+		"/a.added":       PatchAdd,
+		"/a.removed":     PatchRemove,
+		"/a.string":      PatchReplace,
+		"/a.vector/n~11": PatchReplace,
+	}
+	if len(p) != len(expect) {
+		t.Fatalf("expected %d changes, received %d: %v", len(expect), len(p), p)
+	}
+	for _, c := range p {
+		if op, ok := expect[c.pointer()]; !ok || op != c.Op {
+			t.Errorf("unexpected change %s %s", c.Op, c.pointer())
+		}
+	}
+
+	if err := a.Apply(p); err != nil {
+		t.Fatal(err)
+	}
+	if r := Diff(a, b); len(r) != 0 {
+		t.Errorf("expected no difference after applying patch, received %v", r)
+	}
+	if s := a.ToVector("a.vector").ToString("n/1"); s != "TWO" {
+		t.Errorf("nested patch not applied, received %s", s)
+	}
+
+	bad := Patch{&Change{PatchRemove, []string{"not.a.key"}, nil, nil}}
+	if err := a.Apply(append(Diff(a, New("EMPTY")), bad...)); err == nil {
+		t.Error("expected error applying patch with missing target")
+	}
+	if a.Tag() != "DIFF" {
+		t.Error("failed patch was partially applied")
+	}
+}
+
+func TestDiffTags(t *testing.T) {
+	a, b := diffVectors()
+	b.Retag("OTHER")
+	nb := b.ToVector("a.vector")
+	nb.Retag("other")
+	b.SetVector("a.vector", nb)
+	for _, c := range Diff(a, b) {
+		if inList("vector.tag", c.Path) {
+			t.Errorf("identifying key diffed: %s", c.pointer())
+		}
+	}
+	if err := a.Apply(Diff(a, b)); err != nil {
+		t.Fatal(err)
+	}
+	if a.Tag() != "DIFF" || a.ToVector("a.vector").Tag() != "nested" {
+		t.Errorf("patch retagged the target: %s, %s", a.Tag(), a.ToVector("a.vector").Tag())
+	}
+}
+
+func TestPatchTransmission(t *testing.T) {
+	a, b := diffVectors()
+	p := Diff(a, b)
+
+	j, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(j), `{"op":"add","path":"/a.added","value":["x","y"],"type":"strings"}`) {
+		t.Errorf("json patch not in RFC 6902 form: %s", j)
+	}
+	y, err := yaml.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var jp, yp Patch
+	if err := json.Unmarshal(j, &jp); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(y, &yp); err != nil {
+		t.Fatal(err)
+	}
+	for _, rp := range []Patch{jp, yp} {
+		c, _ := diffVectors()
+		if err := c.Apply(rp); err != nil {
+			t.Fatal(err)
+		}
+		if s := c.ToString("a.string"); s != "two" {
+			t.Errorf("transmitted patch not applied, received %s", s)
+		}
+		if l := c.ToStrings("a.added"); len(l) != 2 {
+			t.Errorf("transmitted patch not applied, received %v", l)
+		}
+	}
+}
@@ -8,7 +8,9 @@
 // - Item
 //   A general interface for managing a key and a value. A key is a string and
 //   a value may be anything. The package provides for a variety of common types,
-//   providing example for any type you might have need to construct.
+//   providing example for any type you might have need to construct, and a
+//   generic TypedItem holding a value of any type natively. Types registered
+//   with RegisterItemType are restored by name from any Store.
 //
 // - Vector
 //   A sync.Mutex bound struct wrapping a Trie holding any number of package
@@ -0,0 +1,164 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+//
+type EnvOption func(*envConfig)
+
+type envConfig struct {
+	sep     string
+	fold    func(string) string
+	environ []string
+}
+
+// Sets the separator between the parts of an environment variable name,
+// mapped to the dots of a key, "_" by default. A separator of "__" maps
+// APP_DB__MAX_CONNS to db.max_conns.
+func EnvSeparator(sep string) EnvOption {
+	return func(c *envConfig) {
+		c.sep = sep
+	}
+}
+
+// Sets the function mapping each part of an environment variable name to a
+// key, strings.ToLower by default.
+func EnvCase(fn func(string) string) EnvOption {
+	return func(c *envConfig) {
+		c.fold = fn
+	}
+}
+
+// Sets the environment read, as "NAME=value" strings, os.Environ() by default.
+func EnvFrom(environ []string) EnvOption {
+	return func(c *envConfig) {
+		c.environ = environ
+	}
+}
+
+// Returns a new Vector tagged with the provided prefix, holding a StringItem for
+// each environment variable named with the prefix, e.g. for the prefix "APP",
+// APP_DB_HOST=localhost as db.host. The prefix is followed by either "_" or the
+// separator, so that for the prefix "APP" and separator "__" both
+// APP_DB__MAX_CONNS and APP__DB__MAX_CONNS are read as db.max_conns. Values
+// are held as strings, numeric and boolean values being read as such by any
+// ToX method.
+func FromEnv(prefix string, opts ...EnvOption) *Vector {
+	c := &envConfig{sep: "_", fold: strings.ToLower}
+	for _, o := range opts {
+		o(c)
+	}
+	if c.environ == nil {
+		c.environ = os.Environ()
+	}
+	prefix = strings.TrimSuffix(strings.TrimSuffix(prefix, c.sep), "_")
+	v := New(prefix)
+	for _, e := range c.environ {
+		name, value, ok := strings.Cut(e, "=")
+		if !ok {
+			continue
+		}
+		rest, ok := c.trim(name, prefix)
+		if !ok {
+			continue
+		}
+		var parts []string
+		for _, p := range strings.Split(rest, c.sep) {
+			if p != "" {
+				parts = append(parts, c.fold(p))
+			}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		i := NewStringItem(strings.Join(parts, "."), value)
+		i.Meta().Source = "env:" + name
+		v.Set(i)
+	}
+	return v
+}
+
+// Returns the provided name without the prefix and the separator or "_"
+// following it, or false where the name is not prefixed.
+func (c *envConfig) trim(name, prefix string) (string, bool) {
+	if prefix == "" {
+		return name, true
+	}
+	for _, s := range []string{c.sep, "_"} {
+		if s != "" && strings.HasPrefix(name, prefix+s) {
+			return name[len(prefix+s):], true
+		}
+	}
+	return "", false
+}
+
+var envUnsafe = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// Returns the Item held by this Vector as "NAME=value" strings suitable for
+// os/exec, sorted by name. A key maps to an upper case name following the
+// provided prefix, e.g. for the prefix "APP", db.host as APP_DB_HOST, any
+// VectorItem or MapItem having the keys it holds mapped in turn. Scalar values
+// are written as text, any other as json. Any key holding a FuncItem that fails
+// to evaluate is omitted.
+func (v *Vector) Environ(prefix string) []string {
+	if prefix = strings.TrimSuffix(prefix, "_"); prefix != "" {
+		prefix = prefix + "_"
+	}
+	var ret []string
+	for _, i := range v.readList() {
+		if !identifying(i.Key()) {
+			ret = environ(ret, prefix+envName(i.Key()), i.Provided())
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func envName(k string) string {
+	return strings.Trim(strings.ToUpper(envUnsafe.ReplaceAllString(k, "_")), "_")
+}
+
+func environ(ret []string, name string, vi interface{}) []string {
+	switch val := vi.(type) {
+	case *Vector:
+		for _, i := range val.readList() {
+			if !identifying(i.Key()) {
+				ret = environ(ret, name+"_"+envName(i.Key()), i.Provided())
+			}
+		}
+		return ret
+	case map[string]interface{}:
+		for k, e := range val {
+			ret = environ(ret, name+"_"+envName(k), e)
+		}
+		return ret
+	}
+	return append(ret, name+"="+envValue(vi))
+}
+
+func envValue(vi interface{}) string {
+	switch val := vi.(type) {
+	case string:
+		return val
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	case *big.Float:
+		return val.Text('g', -1)
+	case *big.Rat:
+		return decimalString(val)
+	case fmt.Stringer:
+		return val.String()
+	case []byte, []string, []int, []float64, []bool, []interface{}:
+		b, _ := json.Marshal(val)
+		return string(b)
+	}
+	return fmt.Sprint(vi)
+}
@@ -0,0 +1,85 @@
+package data
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFromEnv(t *testing.T) {
+	env := []string{
+		"APP_DB_HOST=localhost",
+		"APP_DB_PORT=5432",
+		"APP_DEBUG=true",
+		"APP_NAME=a=b",
+		"APPLICATION=other",
+		"HOME=/root",
+	}
+	v := FromEnv("APP", EnvFrom(env))
+	if v.Tag() != "APP" {
+		t.Errorf("expected tag APP, received %s", v.Tag())
+	}
+	if v.ToString("db.host") != "localhost" || v.ToInt("db.port") != 5432 || !v.ToBool("debug") || v.ToString("name") != "a=b" {
+		t.Errorf("unexpected env vector %v", v.TemplateData())
+	}
+	if v.Get("application") != nil || v.Get("cation") != nil || v.Get("home") != nil {
+		t.Error("env variable outside prefix read")
+	}
+	if src := v.Get("db.host").Meta().Source; src != "env:APP_DB_HOST" {
+		t.Errorf("expected env source, received %s", src)
+	}
+
+	v = FromEnv("APP_", EnvFrom([]string{"APP_DB__MAX_CONNS=10"}), EnvSeparator("__"), EnvCase(strings.ToLower))
+	if v.ToInt("db.max_conns") != 10 {
+		t.Errorf("expected custom separator, received %v", v.Keys())
+	}
+	v = FromEnv("APP", EnvFrom([]string{"APP_DB__MAX_CONNS=10", "APP__LOG__LEVEL=info", "APPX__NAME=other"}), EnvSeparator("__"))
+	if v.ToInt("db.max_conns") != 10 || v.ToString("log.level") != "info" || v.Get("name") != nil || v.Get("x.name") != nil {
+		t.Errorf("expected prefix followed by _ or separator, received %v", v.Keys())
+	}
+
+	t.Setenv("DATA_TEST_ENV_KEY", "value")
+	if v = FromEnv("DATA_TEST"); v.ToString("env.key") != "value" {
+		t.Error("expected process environment read")
+	}
+}
+
+func TestEnviron(t *testing.T) {
+	v := New("ENVIRON")
+	v.SetString("db.host", "localhost")
+	v.SetInt("db.port", 5432)
+	v.SetBool("debug", true)
+	v.SetStrings("hosts", "a", "b")
+	v.SetDuration("timeout", 30*time.Second)
+	v.SetString("log-level", "info")
+	n := New("NESTED")
+	n.SetString("cert", "/tls.crt")
+	v.SetVector("tls", n)
+	v.SetMap("cache", map[string]interface{}{"size": 10})
+
+	want := []string{
+		"APP_CACHE_SIZE=10",
+		"APP_DB_HOST=localhost",
+		"APP_DB_PORT=5432",
+		"APP_DEBUG=true",
+		`APP_HOSTS=["a","b"]`,
+		"APP_LOG_LEVEL=info",
+		"APP_TIMEOUT=30s",
+		"APP_TLS_CERT=/tls.crt",
+	}
+	if got := v.Environ("APP"); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, received %v", want, got)
+	}
+
+	r := FromEnv("APP", EnvFrom(want))
+	if r.ToString("db.host") != "localhost" || r.ToInt("db.port") != 5432 || r.ToDuration("timeout") != 30*time.Second {
+		t.Errorf("expected environ to round trip, received %v", r.TemplateData())
+	}
+
+	v.Set(NewFuncItem("fails", func(*Vector) (interface{}, error) { return nil, errors.New("fails") }))
+	if got := v.Environ("APP"); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected failing item omitted, received %v", got)
+	}
+}
@@ -0,0 +1,149 @@
+package data
+
+import (
+	"math/big"
+	"reflect"
+
+	"github.com/Laughs-In-Flowers/xrr"
+)
+
+var ErrFrozen = xrr.Xrror("vector is frozen")
+
+// Freezes this Vector, after which any change to it fails with ErrFrozen, or
+// for a method not returning an error, is ignored. Item retrieved from a
+// frozen Vector are copies, holding copies of any slice or map and a frozen
+// view of any Vector, and changing one, e.g. with Provide, leaves the Vector
+// untouched. A Vector cannot be thawed; see Snapshot and Clone for a
+// changeable copy.
+func (v *Vector) Freeze() {
+	v.l.Lock()
+	v.frozen = true
+	v.l.Unlock()
+}
+
+// Returns a frozen view of this Vector as it stands, to be handed to readers
+// that must not change it. The Vector itself remains changeable, and shares
+// structure with the view until it is changed.
+func (v *Vector) ReadOnly() *Vector {
+	r := v.Snapshot().Vector()
+	v.l.RLock()
+	r.tm = v.tm
+	v.l.RUnlock()
+	r.frozen = true
+	return r
+}
+
+// Returns true if this Vector is frozen.
+func (v *Vector) Frozen() bool {
+	v.l.RLock()
+	defer v.l.RUnlock()
+	return v.frozen
+}
+
+// Returns a copy of the provided Item for a frozen Vector. Must be called
+// under lock.
+func (v *Vector) out(i Item) Item {
+	if v.frozen && i != nil {
+		return copyItem(i, true)
+	}
+	return i
+}
+
+// Returns a copy of the provided Item sharing nothing changeable with it: any
+// slice, map, or big number held is copied, and any Vector held is copied as
+// a frozen view where frozen.
+func copyItem(i Item, frozen bool) Item {
+	c := i.Clone()
+	if p := i.Provided(); p != nil {
+		c.Provide(copyValue(p, frozen))
+	}
+	if e := c.Meta().Expires; e != nil {
+		t := *e
+		c.Meta().Expires = &t
+	}
+	return c
+}
+
+func copyValue(vi interface{}, frozen bool) interface{} {
+	switch val := vi.(type) {
+	case *Vector:
+		if frozen {
+			return val.ReadOnly()
+		}
+		return val.Snapshot().Vector()
+	case *big.Int:
+		return new(big.Int).Set(val)
+	case *big.Float:
+		return new(big.Float).Copy(val)
+	case *big.Rat:
+		return new(big.Rat).Set(val)
+	case []byte:
+		return append([]byte(nil), val...)
+	}
+	rv := reflect.ValueOf(vi)
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return vi
+		}
+		c := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		switch rv.Type().Elem().Kind() {
+		case reflect.Interface, reflect.Slice, reflect.Map, reflect.Ptr:
+			for n := 0; n < rv.Len(); n++ {
+				c.Index(n).Set(copyElem(rv.Index(n), frozen))
+			}
+		default:
+			reflect.Copy(c, rv)
+		}
+		return c.Interface()
+	case reflect.Map:
+		if rv.IsNil() {
+			return vi
+		}
+		c := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		for it := rv.MapRange(); it.Next(); {
+			c.SetMapIndex(it.Key(), copyElem(it.Value(), frozen))
+		}
+		return c.Interface()
+	}
+	return vi
+}
+
+func copyElem(e reflect.Value, frozen bool) reflect.Value {
+	if e.IsNil() {
+		return e
+	}
+	return reflect.ValueOf(copyValue(e.Interface(), frozen)).Convert(e.Type())
+}
+
+// Sets the provided Item as Set, or returns ErrFrozen for a frozen Vector.
+func (v *Vector) TrySet(i ...Item) error {
+	v.l.Lock()
+	defer v.l.Unlock()
+	if v.frozen {
+		return ErrFrozen
+	}
+	v.setItems(OpSet, i...)
+	return nil
+}
+
+// Deletes the Item at each provided key as Delete, or returns ErrFrozen for a
+// frozen Vector.
+func (v *Vector) TryDelete(keys ...string) (int, error) {
+	v.l.Lock()
+	defer v.l.Unlock()
+	if v.frozen {
+		return 0, ErrFrozen
+	}
+	return v.remove(keys...), nil
+}
+
+// Clears the Vector as Clear, or returns ErrFrozen for a frozen Vector.
+func (v *Vector) TryClear() error {
+	return v.clear(nil)
+}
+
+// Resets the Vector as Reset, or returns ErrFrozen for a frozen Vector.
+func (v *Vector) TryReset() error {
+	return v.clear(v.Match("vector"))
+}
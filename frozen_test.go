@@ -0,0 +1,162 @@
+package data
+
+import "testing"
+
+func TestReadOnly(t *testing.T) {
+	v := base.Clone()
+	r := v.ReadOnly()
+	if v.Frozen() || !r.Frozen() {
+		t.Fatal("expected only the view to be frozen")
+	}
+
+	if err := r.TrySet(NewIntItem("a.int", 100)); err != ErrFrozen {
+		t.Errorf("expected ErrFrozen setting, received %v", err)
+	}
+	if n, err := r.TryDelete("a.int"); n != 0 || err != ErrFrozen {
+		t.Errorf("expected ErrFrozen deleting, received %d, %v", n, err)
+	}
+	if err := r.TryClear(); err != ErrFrozen {
+		t.Errorf("expected ErrFrozen clearing, received %v", err)
+	}
+	if err := r.TryReset(); err != ErrFrozen {
+		t.Errorf("expected ErrFrozen resetting, received %v", err)
+	}
+	if err := r.SetPath("a.path", "value"); err != ErrFrozen {
+		t.Errorf("expected ErrFrozen setting path, received %v", err)
+	}
+	if _, err := r.MergeWith(MergeOverwrite, v); err != ErrFrozen {
+		t.Errorf("expected ErrFrozen merging, received %v", err)
+	}
+	if err := r.UnmarshalJSON([]byte(`[{"key":"a.int","value":100}]`)); err != ErrFrozen {
+		t.Errorf("expected ErrFrozen unmarshaling, received %v", err)
+	}
+	kl := len(r.Keys())
+	r.SetInt("a.int", 100)
+	r.Delete("a.string")
+	r.Clear()
+	r.Reset()
+	r.Retag("RETAGGED")
+	if r.ToInt("a.int") != 9 || r.Tag() != v.Tag() || len(r.Keys()) != kl {
+		t.Error("frozen view changed")
+	}
+
+	r.Get("a.int").Provide(100)
+	r.List()[0].Provide("changed")
+	r.Snapshot().Get("a.int").Provide(100)
+	r.Snapshot().Vector().Get("a.int").Provide(100)
+	if r.ToInt("a.int") != 9 || v.ToInt("a.int") != 9 {
+		t.Error("item changed through frozen view")
+	}
+	for _, i := range r.List() {
+		if i.Provided() == "changed" {
+			t.Errorf("item %s changed through frozen view", i.Key())
+		}
+	}
+
+	v.SetInt("a.int", 100)
+	if r.ToInt("a.int") != 9 {
+		t.Error("frozen view changed with vector")
+	}
+	if err := v.TrySet(NewStringItem("b.new", "new")); err != nil || v.ToString("b.new") != "new" {
+		t.Errorf("unable to set on vector: %v", err)
+	}
+}
+
+func TestReadOnlyValues(t *testing.T) {
+	v := New("VALUES")
+	v.SetStrings("a.strings", "x", "y")
+	v.SetMap("a.map", map[string]interface{}{"k": "v", "l": []interface{}{1, 2}})
+	v.SetBytes("a.bytes", []byte("bytes"))
+	n := New("NESTED")
+	n.SetString("n.key", "nested")
+	v.SetVector("a.vector", n)
+	r := v.ReadOnly()
+
+	for _, rv := range []*Vector{r, r.Snapshot().Vector()} {
+		l := rv.ToStrings("a.strings")
+		l[0] = "changed"
+		_ = append(l[:1], "appended")
+		m := rv.ToMap("a.map")
+		m["k"] = "changed"
+		m["l"].([]interface{})[0] = "changed"
+		rv.ToBytes("a.bytes")[0] = 'B'
+		rv.ToVector("a.vector").SetString("n.key", "changed")
+		rv.Get("a.strings").Provided().([]string)[1] = "changed"
+	}
+	for _, rv := range []*Vector{v, r} {
+		if l := rv.ToStrings("a.strings"); l[0] != "x" || l[1] != "y" {
+			t.Errorf("strings changed through frozen view: %v", l)
+		}
+		if m := rv.ToMap("a.map"); m["k"] != "v" || m["l"].([]interface{})[0] != 1 {
+			t.Errorf("map changed through frozen view: %v", m)
+		}
+		if b := string(rv.ToBytes("a.bytes")); b != "bytes" {
+			t.Errorf("bytes changed through frozen view: %s", b)
+		}
+		if s := rv.ToVector("a.vector").ToString("n.key"); s != "nested" {
+			t.Errorf("nested vector changed through frozen view: %s", s)
+		}
+	}
+	if !r.ToVector("a.vector").Frozen() {
+		t.Error("nested vector of frozen view not frozen")
+	}
+}
+
+func TestReadOnlyVisit(t *testing.T) {
+	v := New("VISIT")
+	v.SetString("k", "value")
+	s := v.Snapshot()
+	l := NewLayered("LAYERED")
+	l.Push("base", v)
+	provide := func(_ Prefix, i Item) error {
+		i.Provide("x")
+		return nil
+	}
+	for _, r := range []*Vector{v.ReadOnly(), l.Vector} {
+		r.Visit(provide)
+		r.VisitSubtree(Prefix("k"), provide)
+		r.VisitPrefixes(Prefix("k"), provide)
+		if r.Delete("k") != 0 || r.DeleteSubtree("k") != 0 {
+			t.Error("deleted through frozen view")
+		}
+		if r.ToString("k") != "value" {
+			t.Errorf("frozen view changed through visit: %s", r.ToString("k"))
+		}
+	}
+	if v.ToString("k") != "value" || s.ToString("k") != "value" {
+		t.Errorf("vector changed through frozen view: %s, %s", v.ToString("k"), s.ToString("k"))
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	v := base.Clone()
+	v.Freeze()
+	if !v.Frozen() {
+		t.Fatal("expected vector to be frozen")
+	}
+	c, cancel := v.Watch("")
+	defer cancel()
+	tx := v.Begin()
+	tx.SetString("a.string", "changed")
+	if err := tx.Commit(); err != ErrFrozen {
+		t.Errorf("expected ErrFrozen committing, received %v", err)
+	}
+	if err := v.Apply(Diff(v, New("EMPTY"))); err != ErrFrozen {
+		t.Errorf("expected ErrFrozen applying, received %v", err)
+	}
+	v.Delete("a.string")
+	if v.ToString("a.string") != "string" {
+		t.Error("frozen vector changed")
+	}
+	select {
+	case e := <-c:
+		t.Errorf("unexpected %s event for %s on frozen vector", e.Op, e.Key)
+	default:
+	}
+
+	n := v.Clone()
+	n.SetString("a.string", "changed")
+	if n.Frozen() || n.ToString("a.string") != "changed" {
+		t.Error("unable to change clone of frozen vector")
+	}
+}
@@ -0,0 +1,196 @@
+package data
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/Laughs-In-Flowers/xrr"
+)
+
+var FuncCycleError = xrr.Xrror("func item cycle %s").Out
+
+// An interface for an Item computing its value from the Vector holding it,
+// e.g. a dsn built from a host, port, and user, or a value expensive to fetch.
+// A FuncItem is evaluated as read by Get or any ToX method, and transmits the
+// value it evaluates to, so that any Store emits concrete data. The function
+// reads a frozen view of the Vector, in which a FuncItem read while it is
+// being evaluated, directly or through another FuncItem, fails with
+// FuncCycleError.
+type FuncItem interface {
+	Item
+	Eval(*Vector) (Item, error)
+}
+
+type funcItem struct {
+	Item
+	fn   func(*Vector) (interface{}, error)
+	memo bool
+	deps []string
+	*memoized
+}
+
+type memoized struct {
+	l    sync.Mutex
+	v    *Vector
+	ver  uint64
+	seen []mark
+	val  Item
+}
+
+// Creates a new FuncItem from the provided key and function, evaluated on every
+// read.
+func NewFuncItem(key string, fn func(*Vector) (interface{}, error)) FuncItem {
+	return &funcItem{KeyedItem(key), fn, false, nil, &memoized{}}
+}
+
+// Creates a new FuncItem from the provided key and function, evaluated on first
+// read and again only after the Item held at any of the provided dependency
+// keys changes, or where no dependency is provided, after any change to the
+// Vector.
+func NewMemoFuncItem(key string, fn func(*Vector) (interface{}, error), deps ...string) FuncItem {
+	return &funcItem{KeyedItem(key), fn, true, deps, &memoized{}}
+}
+
+// Returns the Item evaluated from this FuncItem for the provided Vector, keyed
+// and annotated as this FuncItem. The function is called without lock, and may
+// be called concurrently.
+func (i *funcItem) Eval(v *Vector) (Item, error) {
+	k := i.Key()
+	if v.ev != nil && inList(k, v.ev.keys) {
+		return nil, FuncCycleError(strings.Join(append(v.ev.keys, k), " -> "))
+	}
+	b := v.base()
+	ver, seen := b.Version(), make([]mark, len(i.deps))
+	for n, h := range b.held(i.deps...) {
+		seen[n] = markOf(h)
+	}
+	if ri := i.memoized.load(b, ver, seen, i.fresh); ri != nil {
+		return ri, nil
+	}
+	r, err := i.fn(v.evaluating(k))
+	if err != nil {
+		return nil, err
+	}
+	ri := valueItem(k, r)
+	*ri.Meta() = *i.Meta()
+	if i.memo {
+		i.memoized.store(b, ver, seen, ri.Clone())
+	}
+	return ri, nil
+}
+
+// Returns a copy of the memoized Item where evaluated for the provided Vector
+// and fresh, or nil.
+func (m *memoized) load(v *Vector, ver uint64, seen []mark, fresh func(uint64, []mark) bool) Item {
+	m.l.Lock()
+	defer m.l.Unlock()
+	if m.val != nil && m.v == v && fresh(ver, seen) {
+		return m.val.Clone()
+	}
+	return nil
+}
+
+func (m *memoized) store(v *Vector, ver uint64, seen []mark, val Item) {
+	m.l.Lock()
+	m.v, m.ver, m.seen, m.val = v, ver, seen, val
+	m.l.Unlock()
+}
+
+// The keys of the FuncItem being evaluated through a view of a Vector, and the
+// Vector viewed.
+type evaluation struct {
+	v    *Vector
+	keys []string
+}
+
+// Returns a frozen view of this Vector for the function of the FuncItem at the
+// provided key, recording the key so that the FuncItem is not read while it is
+// being evaluated.
+func (v *Vector) evaluating(k string) *Vector {
+	v.l.RLock()
+	c := *v
+	v.l.RUnlock()
+	ev := &evaluation{v: v.base()}
+	if v.ev != nil {
+		ev.keys = v.ev.keys[:len(v.ev.keys):len(v.ev.keys)]
+	}
+	ev.keys = append(ev.keys, k)
+	c.ev, c.frozen = ev, true
+	return &c
+}
+
+// Returns the Vector viewed while evaluating a FuncItem, or this Vector.
+func (v *Vector) base() *Vector {
+	if v.ev != nil {
+		return v.ev.v
+	}
+	return v
+}
+
+func (i *funcItem) fresh(ver uint64, seen []mark) bool {
+	if len(i.deps) == 0 {
+		return ver == i.ver
+	}
+	for n, s := range seen {
+		if s != i.seen[n] {
+			return false
+		}
+	}
+	return true
+}
+
+// Satisfies the Cloner interface for this FuncItem, the copy sharing any
+// memoized value.
+func (i *funcItem) Clone() Item {
+	return &funcItem{i.Item.Clone(), i.fn, i.memo, i.deps, i.memoized}
+}
+
+// Returns the Item held at each provided key, as held rather than as read.
+func (v *Vector) held(keys ...string) []Item {
+	v.l.RLock()
+	defer v.l.RUnlock()
+	ret := make([]Item, len(keys))
+	for n, k := range keys {
+		ret[n] = v.get(Prefix(k))
+	}
+	return ret
+}
+
+// Returns the Item held by this Vector as read by Get, with any FuncItem
+// evaluated and any RefItem followed, omitting any Item failing to evaluate or
+// resolve.
+func (v *Vector) readList() []Item {
+	return v.read(v.List())
+}
+
+// Returns the provided Item held by this Vector as read by readList.
+func (v *Vector) read(l []Item) []Item {
+	var ret []Item
+	for _, i := range l {
+		var err error
+		switch ii := i.(type) {
+		case FuncItem:
+			i, err = ii.Eval(v)
+		case RefItem:
+			i, err = v.deref(ii.Key(), ii.Ref(), nil)
+		}
+		if err == nil && i != nil {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+// Returns the provided Item with any FuncItem evaluated for this Vector.
+func (v *Vector) evaluated(l []Item) ([]Item, error) {
+	for n, i := range l {
+		if f, ok := i.(FuncItem); ok {
+			ri, err := f.Eval(v)
+			if err != nil {
+				return nil, err
+			}
+			l[n] = ri
+		}
+	}
+	return l, nil
+}
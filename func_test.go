@@ -0,0 +1,167 @@
+package data
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func dsn(v *Vector) (interface{}, error) {
+	return fmt.Sprintf("%s@%s:%d", v.ToString("db.user"), v.ToString("db.host"), v.ToInt("db.port")), nil
+}
+
+func TestFuncItem(t *testing.T) {
+	v := New("FUNC")
+	v.SetString("db.user", "app")
+	v.SetString("db.host", "localhost")
+	v.SetInt("db.port", 5432)
+	var calls int
+	v.Set(NewFuncItem("db.dsn", func(v *Vector) (interface{}, error) {
+		calls++
+		return dsn(v)
+	}))
+
+	if s := v.ToString("db.dsn"); s != "app@localhost:5432" {
+		t.Errorf("expected computed dsn, received %q", s)
+	}
+	v.SetInt("db.port", 6543)
+	if s := v.ToString("db.dsn"); s != "app@localhost:6543" {
+		t.Errorf("expected recomputed dsn, received %q", s)
+	}
+	v.ToString("db.dsn")
+	if calls != 3 {
+		t.Errorf("expected evaluation on every read, received %d evaluations", calls)
+	}
+	if i := v.Get("db.dsn"); i.Key() != "db.dsn" {
+		t.Errorf("expected evaluated item keyed db.dsn, received %s", i.Key())
+	}
+
+	b, err := v.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte(`"app@localhost:6543"`)) {
+		t.Errorf("expected evaluated value transmitted, received %s", b)
+	}
+	var buf bytes.Buffer
+	if err := v.EncodeJSON(&buf); err != nil || !bytes.Equal(buf.Bytes(), b) {
+		t.Errorf("expected encoded json to match marshaled json, received %s, %v", buf.Bytes(), err)
+	}
+	n := New("")
+	if err := n.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := n.Get("db.dsn").(StringItem); !ok {
+		t.Errorf("expected concrete string restored, received %T", n.Get("db.dsn"))
+	}
+	if d := v.TemplateData()["DbDsn"]; d != "app@localhost:6543" {
+		t.Errorf("expected evaluated template data, received %v", d)
+	}
+}
+
+func TestMemoFuncItem(t *testing.T) {
+	v := New("MEMO")
+	v.SetString("db.user", "app")
+	v.SetString("db.host", "localhost")
+	v.SetInt("db.port", 5432)
+	var calls int
+	v.Set(NewMemoFuncItem("db.dsn", func(v *Vector) (interface{}, error) {
+		calls++
+		return dsn(v)
+	}, "db.user", "db.host", "db.port"))
+
+	v.ToString("db.dsn")
+	v.ToString("db.dsn")
+	v.SetString("other", "unrelated")
+	v.Get("db.dsn").Provide("changed")
+	if s := v.ToString("db.dsn"); s != "app@localhost:5432" || calls != 1 {
+		t.Errorf("expected memoized dsn from 1 evaluation, received %q from %d", s, calls)
+	}
+	v.SetString("db.host", "db")
+	if s := v.ToString("db.dsn"); s != "app@db:5432" || calls != 2 {
+		t.Errorf("expected dsn reevaluated on dependency change, received %q from %d", s, calls)
+	}
+
+	calls = 0
+	v.Set(NewMemoFuncItem("any", func(v *Vector) (interface{}, error) {
+		calls++
+		return calls, nil
+	}))
+	v.ToInt("any")
+	v.ToInt("any")
+	v.SetString("other", "changed")
+	if n := v.ToInt("any"); n != 2 {
+		t.Errorf("expected reevaluation on any change, received %d", n)
+	}
+
+	v.Set(uncomparableItem{NewStringItem("dep", "a"), nil})
+	v.Set(NewMemoFuncItem("uses", func(v *Vector) (interface{}, error) {
+		return v.ToString("dep"), nil
+	}, "dep"))
+	v.ToString("uses")
+	v.Set(uncomparableItem{NewStringItem("dep", "b"), nil})
+	if s := v.ToString("uses"); s != "b" {
+		t.Errorf("expected reevaluation on an uncomparable dependency change, received %s", s)
+	}
+}
+
+func TestFuncItemError(t *testing.T) {
+	v := New("FAIL")
+	fail := errors.New("unavailable")
+	v.Set(NewFuncItem("remote", func(*Vector) (interface{}, error) {
+		return nil, fail
+	}))
+	if i := v.Get("remote"); i != nil {
+		t.Errorf("expected nil for failing item, received %v", i)
+	}
+	if _, err := v.Eval("remote"); err != fail {
+		t.Errorf("expected evaluation error, received %v", err)
+	}
+	if _, err := v.MarshalJSON(); err != fail {
+		t.Errorf("expected evaluation error marshaling, received %v", err)
+	}
+	v.SetString("local", "value")
+	d := v.TemplateData()
+	if _, ok := d["Remote"]; ok || d["Local"] != "value" || d["VectorTag"] != "FAIL" {
+		t.Errorf("expected template data omitting only the failing item, received %v", d)
+	}
+}
+
+func TestFuncItemCycle(t *testing.T) {
+	v := New("CYCLE")
+	v.Set(NewFuncItem("self", func(v *Vector) (interface{}, error) {
+		return v.Eval("self")
+	}))
+	v.Set(NewMemoFuncItem("a", func(v *Vector) (interface{}, error) {
+		return v.Eval("b")
+	}))
+	v.Set(NewFuncItem("b", func(v *Vector) (interface{}, error) {
+		return v.Eval("a")
+	}))
+	for _, k := range []string{"self", "a", "b"} {
+		done := make(chan error, 1)
+		go func() {
+			_, err := v.Eval(k)
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			if err == nil || !strings.Contains(err.Error(), "cycle") {
+				t.Errorf("expected cycle error evaluating %s, received %v", k, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("evaluating %s did not return", k)
+		}
+	}
+
+	v.Set(NewFuncItem("writes", func(v *Vector) (interface{}, error) {
+		v.SetString("written", "value")
+		return v.Frozen(), nil
+	}))
+	if !v.ToBool("writes") || v.Get("written") != nil {
+		t.Error("expected function to read a frozen view")
+	}
+}
@@ -0,0 +1,809 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/Laughs-In-Flowers/xrr"
+)
+
+var (
+	keyNotFound  = xrr.Xrror("key %s not found")
+	typeMismatch = xrr.Xrror("key %s holds %s, not %s")
+	parseFailure = xrr.Xrror("key %s holds string %q, not parsable as %s: %s")
+	overflow     = xrr.Xrror("key %s holds %v, overflowing %s")
+)
+
+// An error returned when no Item is held at Key.
+type ErrKeyNotFound struct {
+	Key string
+}
+
+//
+func (e *ErrKeyNotFound) Error() string {
+	return keyNotFound.Out(e.Key).Error()
+}
+
+// An error returned when the Item held at Key is of type Got, where type Want
+// was requested.
+type ErrTypeMismatch struct {
+	Key  string
+	Want string
+	Got  string
+}
+
+//
+func (e *ErrTypeMismatch) Error() string {
+	return typeMismatch.Out(e.Key, e.Got, e.Want).Error()
+}
+
+// An error returned when the StringItem held at Key holds Value, which could
+// not be parsed as type Want.
+type ErrParse struct {
+	Key   string
+	Want  string
+	Value string
+	Err   error
+}
+
+//
+func (e *ErrParse) Error() string {
+	return parseFailure.Out(e.Key, e.Value, e.Want, e.Err).Error()
+}
+
+// An error returned when the Item held at Key holds Value, which is out of
+// range for type Want.
+type ErrOverflow struct {
+	Key   string
+	Want  string
+	Value interface{}
+}
+
+//
+func (e *ErrOverflow) Error() string {
+	return overflow.Out(e.Key, e.Value, e.Want).Error()
+}
+
+// Returns a short name for the type of the provided Item.
+func itemType(i Item) string {
+	if n := itemTypeName(i); n != "" {
+		return n
+	}
+	return fmt.Sprintf("%T", i.Provided())
+}
+
+func check(k string, i Item, want string) error {
+	if i == nil {
+		return &ErrKeyNotFound{k}
+	}
+	return &ErrTypeMismatch{i.Key(), want, itemType(i)}
+}
+
+// Returns the value parsed from the StringItem, or the zero value where it
+// fails to parse.
+func parse[T any](i StringItem, want string, fn func(string) (T, error)) (T, error) {
+	s := i.ToString()
+	r, err := fn(s)
+	if err != nil {
+		var zero T
+		return zero, &ErrParse{i.Key(), want, s, err}
+	}
+	return r, nil
+}
+
+func getString(k string, i Item) (string, error) {
+	switch ii := i.(type) {
+	case StringItem:
+		return ii.ToString(), nil
+	case TimeItem:
+		return ii.ToTime().Format(time.RFC3339Nano), nil
+	case DurationItem:
+		return ii.ToDuration().String(), nil
+	}
+	return "", check(k, i, "string")
+}
+
+func getStrings(k string, i Item) ([]string, error) {
+	if ii, ok := i.(StringsItem); ok {
+		return ii.ToStrings(), nil
+	}
+	return nil, check(k, i, "strings")
+}
+
+func getInts(k string, i Item) ([]int, error) {
+	switch ii := i.(type) {
+	case IntsItem:
+		return ii.ToInts(), nil
+	case StringsItem:
+		return parseEach(ii, "ints", strconv.Atoi)
+	}
+	return nil, check(k, i, "ints")
+}
+
+func getFloat64s(k string, i Item) ([]float64, error) {
+	switch ii := i.(type) {
+	case Float64sItem:
+		return ii.ToFloat64s(), nil
+	case StringsItem:
+		return parseEach(ii, "float64s", func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 64)
+		})
+	}
+	return nil, check(k, i, "float64s")
+}
+
+func getBools(k string, i Item) ([]bool, error) {
+	switch ii := i.(type) {
+	case BoolsItem:
+		return ii.ToBools(), nil
+	case StringsItem:
+		return parseEach(ii, "bools", strconv.ParseBool)
+	}
+	return nil, check(k, i, "bools")
+}
+
+// Parses each string held by the StringsItem.
+func parseEach[T any](i StringsItem, want string, fn func(string) (T, error)) ([]T, error) {
+	l := i.ToStrings()
+	ret := make([]T, 0, len(l))
+	for _, s := range l {
+		r, err := fn(s)
+		if err != nil {
+			return nil, &ErrParse{i.Key(), want, s, err}
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}
+
+func getList(k string, i Item) ([]interface{}, error) {
+	if ii, ok := i.(ListItem); ok {
+		return ii.ToList(), nil
+	}
+	return nil, check(k, i, "list")
+}
+
+func getBool(k string, i Item) (bool, error) {
+	switch ii := i.(type) {
+	case BoolItem:
+		return ii.ToBool(), nil
+	case StringItem:
+		return parse(ii, "bool", strconv.ParseBool)
+	}
+	return false, check(k, i, "bool")
+}
+
+// Returns the value of any signed integer Item.
+func signedOf(i Item) (int64, bool) {
+	switch ii := i.(type) {
+	case IntItem:
+		return int64(ii.ToInt()), true
+	case Int8Item:
+		return int64(ii.ToInt8()), true
+	case Int16Item:
+		return int64(ii.ToInt16()), true
+	case Int32Item:
+		return int64(ii.ToInt32()), true
+	case Int64Item:
+		return ii.ToInt64(), true
+	}
+	return 0, false
+}
+
+// Returns the value of any unsigned integer Item.
+func unsignedOf(i Item) (uint64, bool) {
+	switch ii := i.(type) {
+	case UintItem:
+		return uint64(ii.ToUint()), true
+	case Uint8Item:
+		return uint64(ii.ToUint8()), true
+	case Uint16Item:
+		return uint64(ii.ToUint16()), true
+	case Uint32Item:
+		return uint64(ii.ToUint32()), true
+	case Uint64Item:
+		return ii.ToUint64(), true
+	}
+	return 0, false
+}
+
+// Returns a signed integer of the provided bit size from any integer Item
+// holding a value in range, or a parsable StringItem.
+func getSigned(k string, i Item, want string, bits int) (int64, error) {
+	if ii, ok := i.(StringItem); ok {
+		return parse(ii, want, func(s string) (int64, error) {
+			return strconv.ParseInt(s, 10, bits)
+		})
+	}
+	max := int64(1)<<(bits-1) - 1
+	min := -max - 1
+	if n, ok := signedOf(i); ok {
+		if n < min || n > max {
+			return 0, &ErrOverflow{i.Key(), want, n}
+		}
+		return n, nil
+	}
+	if n, ok := unsignedOf(i); ok {
+		if n > uint64(max) {
+			return 0, &ErrOverflow{i.Key(), want, n}
+		}
+		return int64(n), nil
+	}
+	if ii, ok := i.(BigIntItem); ok {
+		n := ii.ToBigInt()
+		if n == nil || !n.IsInt64() || n.Int64() < min || n.Int64() > max {
+			return 0, &ErrOverflow{i.Key(), want, n}
+		}
+		return n.Int64(), nil
+	}
+	return 0, check(k, i, want)
+}
+
+// Returns an unsigned integer of the provided bit size from any integer Item
+// holding a value in range, or a parsable StringItem.
+func getUnsigned(k string, i Item, want string, bits int) (uint64, error) {
+	if ii, ok := i.(StringItem); ok {
+		return parse(ii, want, func(s string) (uint64, error) {
+			return strconv.ParseUint(s, 10, bits)
+		})
+	}
+	max := uint64(1)<<(bits-1)<<1 - 1
+	if n, ok := unsignedOf(i); ok {
+		if n > max {
+			return 0, &ErrOverflow{i.Key(), want, n}
+		}
+		return n, nil
+	}
+	if n, ok := signedOf(i); ok {
+		if n < 0 || uint64(n) > max {
+			return 0, &ErrOverflow{i.Key(), want, n}
+		}
+		return uint64(n), nil
+	}
+	if ii, ok := i.(BigIntItem); ok {
+		n := ii.ToBigInt()
+		if n == nil || !n.IsUint64() || n.Uint64() > max {
+			return 0, &ErrOverflow{i.Key(), want, n}
+		}
+		return n.Uint64(), nil
+	}
+	return 0, check(k, i, want)
+}
+
+func getInt(k string, i Item) (int, error) {
+	r, err := getSigned(k, i, "int", strconv.IntSize)
+	return int(r), err
+}
+
+func getInt8(k string, i Item) (int8, error) {
+	r, err := getSigned(k, i, "int8", 8)
+	return int8(r), err
+}
+
+func getInt16(k string, i Item) (int16, error) {
+	r, err := getSigned(k, i, "int16", 16)
+	return int16(r), err
+}
+
+func getInt32(k string, i Item) (int32, error) {
+	r, err := getSigned(k, i, "int32", 32)
+	return int32(r), err
+}
+
+func getInt64(k string, i Item) (int64, error) {
+	return getSigned(k, i, "int64", 64)
+}
+
+func getUint(k string, i Item) (uint, error) {
+	r, err := getUnsigned(k, i, "uint", strconv.IntSize)
+	return uint(r), err
+}
+
+func getUint8(k string, i Item) (uint8, error) {
+	r, err := getUnsigned(k, i, "uint8", 8)
+	return uint8(r), err
+}
+
+func getUint16(k string, i Item) (uint16, error) {
+	r, err := getUnsigned(k, i, "uint16", 16)
+	return uint16(r), err
+}
+
+func getUint32(k string, i Item) (uint32, error) {
+	r, err := getUnsigned(k, i, "uint32", 32)
+	return uint32(r), err
+}
+
+func getUint64(k string, i Item) (uint64, error) {
+	return getUnsigned(k, i, "uint64", 64)
+}
+
+func getFloat32(k string, i Item) (float32, error) {
+	switch ii := i.(type) {
+	case Float32Item:
+		return ii.ToFloat32(), nil
+	case Float64Item:
+		r := ii.ToFloat64()
+		if math.Abs(r) > math.MaxFloat32 {
+			return 0, &ErrOverflow{i.Key(), "float32", r}
+		}
+		return float32(r), nil
+	case StringItem:
+		r, err := parse(ii, "float32", func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 32)
+		})
+		return float32(r), err
+	}
+	return 0, check(k, i, "float32")
+}
+
+func getFloat64(k string, i Item) (float64, error) {
+	switch ii := i.(type) {
+	case Float64Item:
+		return ii.ToFloat64(), nil
+	case Float32Item:
+		return float64(ii.ToFloat32()), nil
+	case StringItem:
+		return parse(ii, "float64", func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 64)
+		})
+	}
+	return 0, check(k, i, "float64")
+}
+
+func getBigInt(k string, i Item) (*big.Int, error) {
+	r := new(big.Int)
+	switch ii := i.(type) {
+	case BigIntItem:
+		return ii.ToBigInt(), nil
+	case StringItem:
+		return parse(ii, "bigint", func(s string) (*big.Int, error) {
+			if _, ok := r.SetString(s, 10); !ok {
+				return nil, ConversionError(s, "*big.Int")
+			}
+			return r, nil
+		})
+	}
+	if n, ok := signedOf(i); ok {
+		return r.SetInt64(n), nil
+	}
+	if n, ok := unsignedOf(i); ok {
+		return r.SetUint64(n), nil
+	}
+	return nil, check(k, i, "bigint")
+}
+
+func getBigFloat(k string, i Item) (*big.Float, error) {
+	switch ii := i.(type) {
+	case BigFloatItem:
+		return ii.ToBigFloat(), nil
+	case Float64Item:
+		return big.NewFloat(ii.ToFloat64()), nil
+	case Float32Item:
+		return big.NewFloat(float64(ii.ToFloat32())), nil
+	case StringItem:
+		return parse(ii, "bigfloat", parseBigFloat)
+	}
+	return nil, check(k, i, "bigfloat")
+}
+
+func getDecimal(k string, i Item) (*big.Rat, error) {
+	switch ii := i.(type) {
+	case DecimalItem:
+		return ii.ToDecimal(), nil
+	case BigIntItem:
+		return new(big.Rat).SetInt(ii.ToBigInt()), nil
+	case StringItem:
+		return parse(ii, "decimal", ParseDecimal)
+	}
+	if n, ok := signedOf(i); ok {
+		return new(big.Rat).SetInt64(n), nil
+	}
+	if n, ok := unsignedOf(i); ok {
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(n)), nil
+	}
+	return nil, check(k, i, "decimal")
+}
+
+func getTime(k string, i Item) (time.Time, error) {
+	switch ii := i.(type) {
+	case TimeItem:
+		return ii.ToTime(), nil
+	case StringItem:
+		return parse(ii, "time", func(s string) (time.Time, error) {
+			return time.Parse(time.RFC3339Nano, s)
+		})
+	}
+	return time.Time{}, check(k, i, "time")
+}
+
+func getDuration(k string, i Item) (time.Duration, error) {
+	switch ii := i.(type) {
+	case DurationItem:
+		return ii.ToDuration(), nil
+	case StringItem:
+		return parse(ii, "duration", time.ParseDuration)
+	}
+	return 0, check(k, i, "duration")
+}
+
+func getVector(k string, i Item) (*Vector, error) {
+	if ii, ok := i.(VectorItem); ok {
+		return ii.ToVector(), nil
+	}
+	return nil, check(k, i, "vector")
+}
+
+func getMap(k string, i Item) (map[string]interface{}, error) {
+	if ii, ok := i.(MapItem); ok {
+		return ii.ToMap(), nil
+	}
+	return nil, check(k, i, "map")
+}
+
+func getBytes(k string, i Item) ([]byte, error) {
+	switch ii := i.(type) {
+	case BytesItem:
+		return ii.ToBytes(), nil
+	case StringItem:
+		return []byte(ii.ToString()), nil
+	}
+	return nil, check(k, i, "bytes")
+}
+
+// Return a string from a key matching a stored StringItem, or an error.
+func (v *Vector) GetString(k string) (string, error) {
+	return getString(k, v.Get(k))
+}
+
+// Return an array of strings from a key matching a stored StringsItem, or an
+// error.
+func (v *Vector) GetStrings(k string) ([]string, error) {
+	return getStrings(k, v.Get(k))
+}
+
+// Return a boolean from a key matching a stored BoolItem or parsable
+// StringItem, or an error.
+func (v *Vector) GetBool(k string) (bool, error) {
+	return getBool(k, v.Get(k))
+}
+
+// Return an integer from a key matching a stored IntItem, any integer Item
+// holding a value in range, or a parsable StringItem, or an error.
+func (v *Vector) GetInt(k string) (int, error) {
+	return getInt(k, v.Get(k))
+}
+
+// Return an int64 from a key matching a stored Int64Item, any integer Item
+// holding a value in range, or a parsable StringItem, or an error.
+func (v *Vector) GetInt64(k string) (int64, error) {
+	return getInt64(k, v.Get(k))
+}
+
+// Return an uint from a key matching a stored UintItem, any integer Item
+// holding a value in range, or a parsable StringItem, or an error.
+func (v *Vector) GetUint(k string) (uint, error) {
+	return getUint(k, v.Get(k))
+}
+
+// Return an uint64 from a key matching a stored Uint64Item, any integer Item
+// holding a value in range, or a parsable StringItem, or an error.
+func (v *Vector) GetUint64(k string) (uint64, error) {
+	return getUint64(k, v.Get(k))
+}
+
+// Return a float64 from a key matching a stored Float64Item or Float32Item,
+// or a parsable StringItem, or an error.
+func (v *Vector) GetFloat64(k string) (float64, error) {
+	return getFloat64(k, v.Get(k))
+}
+
+// Return a time.Time from a key matching a stored TimeItem or RFC 3339
+// StringItem, or an error.
+func (v *Vector) GetTime(k string) (time.Time, error) {
+	return getTime(k, v.Get(k))
+}
+
+// Return a time.Duration from a key matching a stored DurationItem or
+// parsable StringItem, or an error.
+func (v *Vector) GetDuration(k string) (time.Duration, error) {
+	return getDuration(k, v.Get(k))
+}
+
+// Return a *Vector from a key matching a stored VectorItem, or an error.
+func (v *Vector) GetVector(k string) (*Vector, error) {
+	return getVector(k, v.Get(k))
+}
+
+// Return a map from a key matching a stored MapItem, or an error.
+func (v *Vector) GetMap(k string) (map[string]interface{}, error) {
+	return getMap(k, v.Get(k))
+}
+
+// Return a []byte from a key matching a stored BytesItem or StringItem, or an
+// error.
+func (v *Vector) GetBytes(k string) ([]byte, error) {
+	return getBytes(k, v.Get(k))
+}
+
+// Return an int8 from a key matching a stored Int8Item, any integer Item
+// holding a value in range, or a parsable StringItem, or an error.
+func (v *Vector) GetInt8(k string) (int8, error) {
+	return getInt8(k, v.Get(k))
+}
+
+// Return an int16 from a key matching a stored Int16Item, any integer Item
+// holding a value in range, or a parsable StringItem, or an error.
+func (v *Vector) GetInt16(k string) (int16, error) {
+	return getInt16(k, v.Get(k))
+}
+
+// Return an int32 from a key matching a stored Int32Item, any integer Item
+// holding a value in range, or a parsable StringItem, or an error.
+func (v *Vector) GetInt32(k string) (int32, error) {
+	return getInt32(k, v.Get(k))
+}
+
+// Return an uint8 from a key matching a stored Uint8Item, any integer Item
+// holding a value in range, or a parsable StringItem, or an error.
+func (v *Vector) GetUint8(k string) (uint8, error) {
+	return getUint8(k, v.Get(k))
+}
+
+// Return an uint16 from a key matching a stored Uint16Item, any integer Item
+// holding a value in range, or a parsable StringItem, or an error.
+func (v *Vector) GetUint16(k string) (uint16, error) {
+	return getUint16(k, v.Get(k))
+}
+
+// Return an uint32 from a key matching a stored Uint32Item, any integer Item
+// holding a value in range, or a parsable StringItem, or an error.
+func (v *Vector) GetUint32(k string) (uint32, error) {
+	return getUint32(k, v.Get(k))
+}
+
+// Return a float32 from a key matching a stored Float32Item, a Float64Item
+// holding a value in range, or a parsable StringItem, or an error.
+func (v *Vector) GetFloat32(k string) (float32, error) {
+	return getFloat32(k, v.Get(k))
+}
+
+// Return a *big.Int from a key matching a stored BigIntItem, any integer
+// Item, or a parsable StringItem, or an error.
+func (v *Vector) GetBigInt(k string) (*big.Int, error) {
+	return getBigInt(k, v.Get(k))
+}
+
+// Return a *big.Float from a key matching a stored BigFloatItem, any float
+// Item, or a parsable StringItem, or an error.
+func (v *Vector) GetBigFloat(k string) (*big.Float, error) {
+	return getBigFloat(k, v.Get(k))
+}
+
+// Return a *big.Rat from a key matching a stored DecimalItem, any integer
+// Item, or a decimal or fraction StringItem, or an error.
+func (v *Vector) GetDecimal(k string) (*big.Rat, error) {
+	return getDecimal(k, v.Get(k))
+}
+
+// Return an array of integers from a key matching a stored IntsItem or
+// parsable StringsItem, or an error.
+func (v *Vector) GetInts(k string) ([]int, error) {
+	return getInts(k, v.Get(k))
+}
+
+// Return an array of float64 from a key matching a stored Float64sItem or
+// parsable StringsItem, or an error.
+func (v *Vector) GetFloat64s(k string) ([]float64, error) {
+	return getFloat64s(k, v.Get(k))
+}
+
+// Return an array of booleans from a key matching a stored BoolsItem or
+// parsable StringsItem, or an error.
+func (v *Vector) GetBools(k string) ([]bool, error) {
+	return getBools(k, v.Get(k))
+}
+
+// Return an array of any values from a key matching a stored ListItem, or an
+// error.
+func (v *Vector) GetList(k string) ([]interface{}, error) {
+	return getList(k, v.Get(k))
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// GetString, panicking on error.
+func (v *Vector) MustString(k string) string {
+	r, err := v.GetString(k)
+	must(err)
+	return r
+}
+
+// GetStrings, panicking on error.
+func (v *Vector) MustStrings(k string) []string {
+	r, err := v.GetStrings(k)
+	must(err)
+	return r
+}
+
+// GetBool, panicking on error.
+func (v *Vector) MustBool(k string) bool {
+	r, err := v.GetBool(k)
+	must(err)
+	return r
+}
+
+// GetInt, panicking on error.
+func (v *Vector) MustInt(k string) int {
+	r, err := v.GetInt(k)
+	must(err)
+	return r
+}
+
+// GetInt64, panicking on error.
+func (v *Vector) MustInt64(k string) int64 {
+	r, err := v.GetInt64(k)
+	must(err)
+	return r
+}
+
+// GetUint, panicking on error.
+func (v *Vector) MustUint(k string) uint {
+	r, err := v.GetUint(k)
+	must(err)
+	return r
+}
+
+// GetUint64, panicking on error.
+func (v *Vector) MustUint64(k string) uint64 {
+	r, err := v.GetUint64(k)
+	must(err)
+	return r
+}
+
+// GetFloat64, panicking on error.
+func (v *Vector) MustFloat64(k string) float64 {
+	r, err := v.GetFloat64(k)
+	must(err)
+	return r
+}
+
+// GetTime, panicking on error.
+func (v *Vector) MustTime(k string) time.Time {
+	r, err := v.GetTime(k)
+	must(err)
+	return r
+}
+
+// GetDuration, panicking on error.
+func (v *Vector) MustDuration(k string) time.Duration {
+	r, err := v.GetDuration(k)
+	must(err)
+	return r
+}
+
+// GetVector, panicking on error.
+func (v *Vector) MustVector(k string) *Vector {
+	r, err := v.GetVector(k)
+	must(err)
+	return r
+}
+
+// GetMap, panicking on error.
+func (v *Vector) MustMap(k string) map[string]interface{} {
+	r, err := v.GetMap(k)
+	must(err)
+	return r
+}
+
+// GetBytes, panicking on error.
+func (v *Vector) MustBytes(k string) []byte {
+	r, err := v.GetBytes(k)
+	must(err)
+	return r
+}
+
+// GetInt8, panicking on error.
+func (v *Vector) MustInt8(k string) int8 {
+	r, err := v.GetInt8(k)
+	must(err)
+	return r
+}
+
+// GetInt16, panicking on error.
+func (v *Vector) MustInt16(k string) int16 {
+	r, err := v.GetInt16(k)
+	must(err)
+	return r
+}
+
+// GetInt32, panicking on error.
+func (v *Vector) MustInt32(k string) int32 {
+	r, err := v.GetInt32(k)
+	must(err)
+	return r
+}
+
+// GetUint8, panicking on error.
+func (v *Vector) MustUint8(k string) uint8 {
+	r, err := v.GetUint8(k)
+	must(err)
+	return r
+}
+
+// GetUint16, panicking on error.
+func (v *Vector) MustUint16(k string) uint16 {
+	r, err := v.GetUint16(k)
+	must(err)
+	return r
+}
+
+// GetUint32, panicking on error.
+func (v *Vector) MustUint32(k string) uint32 {
+	r, err := v.GetUint32(k)
+	must(err)
+	return r
+}
+
+// GetFloat32, panicking on error.
+func (v *Vector) MustFloat32(k string) float32 {
+	r, err := v.GetFloat32(k)
+	must(err)
+	return r
+}
+
+// GetBigInt, panicking on error.
+func (v *Vector) MustBigInt(k string) *big.Int {
+	r, err := v.GetBigInt(k)
+	must(err)
+	return r
+}
+
+// GetBigFloat, panicking on error.
+func (v *Vector) MustBigFloat(k string) *big.Float {
+	r, err := v.GetBigFloat(k)
+	must(err)
+	return r
+}
+
+// GetDecimal, panicking on error.
+func (v *Vector) MustDecimal(k string) *big.Rat {
+	r, err := v.GetDecimal(k)
+	must(err)
+	return r
+}
+
+// GetInts, panicking on error.
+func (v *Vector) MustInts(k string) []int {
+	r, err := v.GetInts(k)
+	must(err)
+	return r
+}
+
+// GetFloat64s, panicking on error.
+func (v *Vector) MustFloat64s(k string) []float64 {
+	r, err := v.GetFloat64s(k)
+	must(err)
+	return r
+}
+
+// GetBools, panicking on error.
+func (v *Vector) MustBools(k string) []bool {
+	r, err := v.GetBools(k)
+	must(err)
+	return r
+}
+
+// GetList, panicking on error.
+func (v *Vector) MustList(k string) []interface{} {
+	r, err := v.GetList(k)
+	must(err)
+	return r
+}
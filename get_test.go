@@ -0,0 +1,107 @@
+package data
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	v := base.Clone()
+	v.SetString("s.int", "12")
+	v.SetString("s.bad", "twelve")
+
+	if i, err := v.GetInt("a.int"); err != nil || i != 9 {
+		t.Errorf("expected 9, received %d, %v", i, err)
+	}
+	if i, err := v.GetInt("s.int"); err != nil || i != 12 {
+		t.Errorf("expected 12 parsed from string, received %d, %v", i, err)
+	}
+	if s, err := v.GetString("a.string"); err != nil || s != "string" {
+		t.Errorf("expected 'string', received %s, %v", s, err)
+	}
+
+	_, err := v.GetInt("not.a.key")
+	if e, ok := err.(*ErrKeyNotFound); !ok || e.Key != "not.a.key" {
+		t.Errorf("expected ErrKeyNotFound, received %v", err)
+	}
+
+	_, err = v.GetBool("a.int")
+	if e, ok := err.(*ErrTypeMismatch); !ok || e.Key != "a.int" || e.Want != "bool" || e.Got != "int" {
+		t.Errorf("expected ErrTypeMismatch, received %v", err)
+	}
+
+	_, err = v.GetFloat64("s.bad")
+	if e, ok := err.(*ErrParse); !ok || e.Value != "twelve" || e.Want != "float64" {
+		t.Errorf("expected ErrParse, received %v", err)
+	}
+
+	if n := v.MustUint64("a.uint64"); n != 111 {
+		t.Errorf("expected 111, received %d", n)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic from Must accessor")
+		}
+	}()
+	v.MustVector("a.string")
+}
+
+func TestGetRange(t *testing.T) {
+	v := New("RANGE")
+	v.SetInt16("a.int16", 300)
+	v.SetInt64("a.int64", -5)
+	v.SetUint64("a.uint64", math.MaxUint64)
+	v.SetString("s.big", "300")
+	v.SetFloat64("a.float64", math.MaxFloat64)
+	v.SetString("s.decimal", "0.1")
+
+	if n, err := v.GetInt32("a.int16"); err != nil || n != 300 {
+		t.Errorf("expected 300 from int16, received %d, %v", n, err)
+	}
+	if n, err := v.GetInt8("a.int64"); err != nil || n != -5 {
+		t.Errorf("expected -5 from int64, received %d, %v", n, err)
+	}
+	for k, fn := range map[string]func(string) error{
+		"a.int16":   func(k string) error { _, err := v.GetInt8(k); return err },
+		"a.int64":   func(k string) error { _, err := v.GetUint(k); return err },
+		"a.uint64":  func(k string) error { _, err := v.GetInt64(k); return err },
+		"a.float64": func(k string) error { _, err := v.GetFloat32(k); return err },
+	} {
+		if e, ok := fn(k).(*ErrOverflow); !ok || e.Key != k {
+			t.Errorf("expected ErrOverflow for %s, received %v", k, fn(k))
+		}
+	}
+	if _, err := v.GetUint8("s.big"); err == nil {
+		t.Error("expected error parsing 300 as uint8")
+	} else if _, ok := err.(*ErrParse); !ok {
+		t.Errorf("expected ErrParse, received %v", err)
+	}
+	if n, err := v.GetBigInt("a.uint64"); err != nil || !n.IsUint64() || n.Uint64() != math.MaxUint64 {
+		t.Errorf("expected bigint from uint64, received %v, %v", n, err)
+	}
+	if d, err := v.GetDecimal("s.decimal"); err != nil || d.Cmp(big.NewRat(1, 10)) != 0 {
+		t.Errorf("expected exact decimal 0.1, received %v, %v", d, err)
+	}
+
+	v.SetString("s.over", "99999999999999999999")
+	v.SetString("s.overfloat", "1e400")
+	if n, err := v.GetInt("s.over"); err == nil || n != 0 {
+		t.Errorf("expected 0 and error parsing overflowing int, received %d, %v", n, err)
+	}
+	if n := v.ToInt("s.over"); n != 0 {
+		t.Errorf("expected 0 from overflowing int, received %d", n)
+	}
+	if n := v.ToInt8("s.big"); n != 0 {
+		t.Errorf("expected 0 from overflowing int8, received %d", n)
+	}
+	if n := v.ToUint("s.over"); n != 0 {
+		t.Errorf("expected 0 from overflowing uint, received %d", n)
+	}
+	if f := v.ToFloat64("s.overfloat"); f != 0 {
+		t.Errorf("expected 0 from overflowing float64, received %v", f)
+	}
+	if f := v.ToFloat32("s.overfloat"); f != 0 {
+		t.Errorf("expected 0 from overflowing float32, received %v", f)
+	}
+}
@@ -1,10 +1,20 @@
 package data
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
-	yaml "gopkg.in/yaml.v2"
+	"github.com/Laughs-In-Flowers/xrr"
+	yaml "gopkg.in/yaml.v3"
 )
 
 // A string key management interface.
@@ -33,10 +43,11 @@ type JsonTransmitter interface {
 	json.Unmarshaler
 }
 
-// A yaml transmitter interface.
+// A yaml transmitter interface, unmarshaled as by the func based
+// yaml.Unmarshaler of gopkg.in/yaml.v2, which gopkg.in/yaml.v3 also accepts.
 type YamlTransmitter interface {
 	yaml.Marshaler
-	yaml.Unmarshaler
+	UnmarshalYAML(func(interface{}) error) error
 }
 
 // An interface for encapsulating item cloning.
@@ -44,19 +55,26 @@ type Cloner interface {
 	Clone() Item
 }
 
+// An interface for reading and annotating the metadata of an item.
+type Metaer interface {
+	Meta() *Meta
+}
+
 // An interface for storing and transmitting single items composed of Keyer,
-// Valuer, Transmitter, and Cloner interfaces.
+// Valuer, Transmitter, Cloner, and Metaer interfaces.
 type Item interface {
 	Keyer
 	Valuer
 	Transmitter
 	Cloner
+	Metaer
 }
 
 type item struct {
 	key      string
 	provided interface{}
 	value    []byte
+	meta     Meta
 }
 
 // Returns the item's string key.
@@ -82,7 +100,12 @@ func (i *item) NewKey(k string) {
 
 // Returns an empty item with the provided key.
 func KeyedItem(k string) Item {
-	return &item{k, nil, nil}
+	return &item{key: k}
+}
+
+// Returns the metadata of this item.
+func (i *item) Meta() *Meta {
+	return &i.meta
 }
 
 // Returns this item's value as a []byte.
@@ -110,10 +133,430 @@ func (i *item) Provide(p interface{}) {
 	i.value = nil
 }
 
-// An intermediary unmarshaling type.
+// An intermediary unmarshaling type. Type, when present, names the type of
+// Item transmitted, e.g. "int", "strings", "vector". Meta is present only
+// where requested, see Vector.TransmitMeta.
 type Mtem struct {
-	Key   string      `json:"key"`
-	Value interface{} `json:"value"`
+	Key   string      `json:"key" yaml:"key"`
+	Value interface{} `json:"value" yaml:"value"`
+	Type  string      `json:"type,omitempty" yaml:"type,omitempty"`
+	Meta  *Meta       `json:"meta,omitempty" yaml:"meta,omitempty"`
+}
+
+// Decodes json, keeping numbers as json.Number so that no precision is lost
+// before the type of Item is known.
+func decodeJSON(b []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+// A function restoring an Item of a named type from a transmitted key and raw
+// value, as decoded from json or yaml.
+type ItemFactory func(key string, raw interface{}) (Item, error)
+
+var (
+	itemTypes     map[string]ItemFactory
+	itemTypeNames = make(map[reflect.Type]string)
+)
+
+var (
+	UnknownItemTypeError = xrr.Xrror("item %s has unknown type %s").Out
+	ItemTypeError        = xrr.Xrror("item %s cannot be restored as %s: %s").Out
+)
+
+// Registers a factory restoring transmitted Item of the named type, replacing
+// any factory previously registered with the name. An Item transmits its type
+// name when it implements NamedItem, or is a TypedItem of a type registered
+// with RegisterTypedItem. Registration is not safe for concurrent use and is
+// best done in an init function.
+func RegisterItemType(name string, factory ItemFactory) {
+	itemTypes[name] = factory
+}
+
+// Registers the named type for TypedItem holding a value of type T, restoring
+// transmitted values by way of their json encoding.
+func RegisterTypedItem[T any](name string) {
+	itemTypeNames[reflect.TypeOf((*T)(nil)).Elem()] = name
+	RegisterItemType(name, func(k string, raw interface{}) (Item, error) {
+		b, err := json.Marshal(plainRaw(raw))
+		if err != nil {
+			return nil, err
+		}
+		var v T
+		if err := decodeJSON(b, &v); err != nil {
+			return nil, err
+		}
+		return NewTypedItem(k, v), nil
+	})
+}
+
+// Returns the raw value as plain go values, with any yaml decoded map keyed by
+// string as json requires, and any json.Number as an int64, uint64, or float64.
+func plainRaw(raw interface{}) interface{} {
+	switch r := raw.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(r))
+		for k, v := range r {
+			ret[k] = plainRaw(v)
+		}
+		return ret
+	case map[interface{}]interface{}:
+		ret := make(map[string]interface{}, len(r))
+		for k, v := range r {
+			ret[fmt.Sprint(k)] = plainRaw(v)
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(r))
+		for n, v := range r {
+			ret[n] = plainRaw(v)
+		}
+		return ret
+	case json.Number:
+		if n, err := r.Int64(); err == nil {
+			return n
+		}
+		if n, err := strconv.ParseUint(string(r), 10, 64); err == nil {
+			return n
+		}
+		f, _ := r.Float64()
+		return f
+	}
+	return raw
+}
+
+func init() {
+	itemTypes = map[string]ItemFactory{
+		"string": func(k string, raw interface{}) (Item, error) {
+			s, ok := raw.(string)
+			if !ok {
+				return nil, ConversionError(raw, "string")
+			}
+			return NewStringItem(k, s), nil
+		},
+		"ref": func(k string, raw interface{}) (Item, error) {
+			s, ok := raw.(string)
+			if !ok {
+				return nil, ConversionError(raw, "string")
+			}
+			return NewRefItem(k, s), nil
+		},
+		"strings": func(k string, raw interface{}) (Item, error) {
+			l, ok := raw.([]interface{})
+			if !ok {
+				return nil, ConversionError(raw, "[]string")
+			}
+			ss := make([]string, 0, len(l))
+			for _, e := range l {
+				s, ok := e.(string)
+				if !ok {
+					return nil, ConversionError(e, "string")
+				}
+				ss = append(ss, s)
+			}
+			return NewStringsItem(k, ss...), nil
+		},
+		"ints": func(k string, raw interface{}) (Item, error) {
+			l, ok := raw.([]interface{})
+			if !ok {
+				return nil, ConversionError(raw, "[]int")
+			}
+			ns := make([]int, 0, len(l))
+			for _, e := range l {
+				n, err := rawInt(e)
+				if err != nil {
+					return nil, err
+				}
+				if int64(int(n)) != n {
+					return nil, OverflowError(n, "int")
+				}
+				ns = append(ns, int(n))
+			}
+			return NewIntsItem(k, ns...), nil
+		},
+		"float64s": func(k string, raw interface{}) (Item, error) {
+			l, ok := raw.([]interface{})
+			if !ok {
+				return nil, ConversionError(raw, "[]float64")
+			}
+			fs := make([]float64, 0, len(l))
+			for _, e := range l {
+				f, err := rawFloat(e)
+				if err != nil {
+					return nil, err
+				}
+				fs = append(fs, f)
+			}
+			return NewFloat64sItem(k, fs...), nil
+		},
+		"bools": func(k string, raw interface{}) (Item, error) {
+			l, ok := raw.([]interface{})
+			if !ok {
+				return nil, ConversionError(raw, "[]bool")
+			}
+			bs := make([]bool, 0, len(l))
+			for _, e := range l {
+				b, ok := e.(bool)
+				if !ok {
+					return nil, ConversionError(e, "bool")
+				}
+				bs = append(bs, b)
+			}
+			return NewBoolsItem(k, bs...), nil
+		},
+		"list": func(k string, raw interface{}) (Item, error) {
+			l, err := rawMtems(raw)
+			if err != nil {
+				return nil, err
+			}
+			es := make([]interface{}, 0, len(l))
+			for _, m := range l {
+				i, err := fromMtem(m)
+				if err != nil {
+					return nil, err
+				}
+				es = append(es, i.Provided())
+			}
+			return NewListItem(k, es...), nil
+		},
+		"bool": func(k string, raw interface{}) (Item, error) {
+			b, ok := raw.(bool)
+			if !ok {
+				return nil, ConversionError(raw, "bool")
+			}
+			return NewBoolItem(k, b), nil
+		},
+		"int": func(k string, raw interface{}) (Item, error) {
+			n, err := rawInt(raw)
+			if err != nil {
+				return nil, err
+			}
+			if int64(int(n)) != n {
+				return nil, OverflowError(n, "int")
+			}
+			return NewIntItem(k, int(n)), nil
+		},
+		"int64": func(k string, raw interface{}) (Item, error) {
+			n, err := rawInt(raw)
+			if err != nil {
+				return nil, err
+			}
+			return NewInt64Item(k, n), nil
+		},
+		"uint": func(k string, raw interface{}) (Item, error) {
+			n, err := rawUint(raw)
+			if err != nil {
+				return nil, err
+			}
+			if uint64(uint(n)) != n {
+				return nil, OverflowError(n, "uint")
+			}
+			return NewUintItem(k, uint(n)), nil
+		},
+		"uint64": func(k string, raw interface{}) (Item, error) {
+			n, err := rawUint(raw)
+			if err != nil {
+				return nil, err
+			}
+			return NewUint64Item(k, n), nil
+		},
+		"int8": func(k string, raw interface{}) (Item, error) {
+			n, err := rawInt(raw)
+			if err != nil {
+				return nil, err
+			}
+			if int64(int8(n)) != n {
+				return nil, OverflowError(n, "int8")
+			}
+			return NewInt8Item(k, int8(n)), nil
+		},
+		"int16": func(k string, raw interface{}) (Item, error) {
+			n, err := rawInt(raw)
+			if err != nil {
+				return nil, err
+			}
+			if int64(int16(n)) != n {
+				return nil, OverflowError(n, "int16")
+			}
+			return NewInt16Item(k, int16(n)), nil
+		},
+		"int32": func(k string, raw interface{}) (Item, error) {
+			n, err := rawInt(raw)
+			if err != nil {
+				return nil, err
+			}
+			if int64(int32(n)) != n {
+				return nil, OverflowError(n, "int32")
+			}
+			return NewInt32Item(k, int32(n)), nil
+		},
+		"uint8": func(k string, raw interface{}) (Item, error) {
+			n, err := rawUint(raw)
+			if err != nil {
+				return nil, err
+			}
+			if uint64(uint8(n)) != n {
+				return nil, OverflowError(n, "uint8")
+			}
+			return NewUint8Item(k, uint8(n)), nil
+		},
+		"uint16": func(k string, raw interface{}) (Item, error) {
+			n, err := rawUint(raw)
+			if err != nil {
+				return nil, err
+			}
+			if uint64(uint16(n)) != n {
+				return nil, OverflowError(n, "uint16")
+			}
+			return NewUint16Item(k, uint16(n)), nil
+		},
+		"uint32": func(k string, raw interface{}) (Item, error) {
+			n, err := rawUint(raw)
+			if err != nil {
+				return nil, err
+			}
+			if uint64(uint32(n)) != n {
+				return nil, OverflowError(n, "uint32")
+			}
+			return NewUint32Item(k, uint32(n)), nil
+		},
+		"float32": func(k string, raw interface{}) (Item, error) {
+			n, err := rawFloat(raw)
+			if err != nil {
+				return nil, err
+			}
+			if math.Abs(n) > math.MaxFloat32 {
+				return nil, OverflowError(n, "float32")
+			}
+			return NewFloat32Item(k, float32(n)), nil
+		},
+		"bigint": func(k string, raw interface{}) (Item, error) {
+			s := fmt.Sprint(raw)
+			n, ok := new(big.Int).SetString(s, 10)
+			if !ok {
+				return nil, ConversionError(raw, "*big.Int")
+			}
+			return NewBigIntItem(k, n), nil
+		},
+		"bigfloat": func(k string, raw interface{}) (Item, error) {
+			f, err := parseBigFloat(fmt.Sprint(raw))
+			if err != nil {
+				return nil, err
+			}
+			return NewBigFloatItem(k, f), nil
+		},
+		"decimal": func(k string, raw interface{}) (Item, error) {
+			r, err := ParseDecimal(fmt.Sprint(raw))
+			if err != nil {
+				return nil, err
+			}
+			return NewDecimalItem(k, r), nil
+		},
+		"float64": func(k string, raw interface{}) (Item, error) {
+			n, err := rawFloat(raw)
+			if err != nil {
+				return nil, err
+			}
+			return NewFloat64Item(k, n), nil
+		},
+		"time": func(k string, raw interface{}) (Item, error) {
+			switch r := raw.(type) {
+			case time.Time:
+				return NewTimeItem(k, r), nil
+			case string:
+				t, err := time.Parse(time.RFC3339Nano, r)
+				if err != nil {
+					return nil, err
+				}
+				return NewTimeItem(k, t), nil
+			}
+			return nil, ConversionError(raw, "time.Time")
+		},
+		"duration": func(k string, raw interface{}) (Item, error) {
+			s, ok := raw.(string)
+			if !ok {
+				return nil, ConversionError(raw, "time.Duration")
+			}
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return nil, err
+			}
+			return NewDurationItem(k, d), nil
+		},
+		"bytes": func(k string, raw interface{}) (Item, error) {
+			switch r := raw.(type) {
+			case []byte:
+				return NewBytesItem(k, r), nil
+			case string:
+				b, err := base64.StdEncoding.DecodeString(r)
+				if err != nil {
+					return nil, err
+				}
+				return NewBytesItem(k, b), nil
+			}
+			return nil, ConversionError(raw, "[]byte")
+		},
+		"binary": func(k string, raw interface{}) (Item, error) {
+			switch r := raw.(type) {
+			case []byte:
+				return NewBytesItem(k, r), nil
+			case string:
+				return NewBytesItem(k, []byte(r)), nil
+			}
+			return nil, ConversionError(raw, "[]byte")
+		},
+		"map": func(k string, raw interface{}) (Item, error) {
+			switch raw.(type) {
+			case map[string]interface{}, map[interface{}]interface{}:
+				return NewMapItem(k, plainRaw(raw).(map[string]interface{})), nil
+			}
+			return nil, ConversionError(raw, "map")
+		},
+		"vector": func(k string, raw interface{}) (Item, error) {
+			l, err := rawMtems(raw)
+			if err != nil {
+				return nil, err
+			}
+			v := New("")
+			for _, m := range l {
+				i, err := fromMtem(m)
+				if err != nil {
+					return nil, err
+				}
+				v.Set(i)
+			}
+			return NewVectorItem(k, v), nil
+		},
+	}
+}
+
+// Returns the Mtem of a transmitted Vector held by a raw value, decoded from
+// json or yaml.
+func rawMtems(raw interface{}) ([]*Mtem, error) {
+	l, ok := raw.([]interface{})
+	if !ok {
+		return nil, ConversionError(raw, "vector")
+	}
+	ret := make([]*Mtem, 0, len(l))
+	for _, e := range l {
+		f := make(map[string]interface{})
+		switch em := e.(type) {
+		case map[string]interface{}:
+			f = em
+		case map[interface{}]interface{}:
+			for mk, mv := range em {
+				f[fmt.Sprint(mk)] = mv
+			}
+		default:
+			return nil, ConversionError(e, "vector")
+		}
+		m := &Mtem{Value: f["value"]}
+		m.Key, _ = f["key"].(string)
+		m.Type, _ = f["type"].(string)
+		ret = append(ret, m)
+	}
+	return ret, nil
 }
 
 func fromVector(i *item) Item {
@@ -121,50 +564,118 @@ func fromVector(i *item) Item {
 
 	var s []string
 	if err := json.Unmarshal(v, &s); err == nil {
-		return &stringsItem{
-			&item{
-				key:      i.key,
-				provided: s,
-			},
-		}
+		i.Provide(s)
+		return &stringsItem{typed[[]string](i)}
 	}
 
 	vec := New("")
 	if err := json.Unmarshal(v, &vec); err == nil {
-		return &vectorItem{
-			&item{
-				key:      i.key,
-				provided: vec,
-			},
-		}
+		i.Provide(vec)
+		return &vectorItem{typed[*Vector](i)}
 	}
 
-	return i
+	var b []bool
+	if err := json.Unmarshal(v, &b); err == nil {
+		i.Provide(b)
+		return &boolsItem{typed[[]bool](i)}
+	}
+
+	var n []int
+	if err := json.Unmarshal(v, &n); err == nil {
+		i.Provide(n)
+		return &intsItem{typed[[]int](i)}
+	}
+
+	var f []float64
+	if err := json.Unmarshal(v, &f); err == nil {
+		i.Provide(f)
+		return &float64sItem{typed[[]float64](i)}
+	}
+
+	i.Provide(plainRaw(i.Provided()))
+	return &listItem{typed[[]interface{}](i)}
+}
+
+// Restores an Item from an Mtem, as the named Type where one is transmitted,
+// otherwise as the type best guessed from the value. An unregistered Type, or a
+// value not restorable as its Type, is an error.
+func fromMtem(m *Mtem) (Item, error) {
+	if m.Type != "" {
+		fn, ok := itemTypes[m.Type]
+		if !ok {
+			return nil, UnknownItemTypeError(m.Key, m.Type)
+		}
+		i, err := fn(m.Key, m.Value)
+		if err != nil {
+			return nil, ItemTypeError(m.Key, m.Type, err)
+		}
+		return i, nil
+	}
+	return guessMtem(m), nil
 }
 
-func fromMtem(m *Mtem) Item {
+func guessMtem(m *Mtem) Item {
 	i := &item{
 		key:      m.Key,
 		provided: m.Value,
 	}
 
-	switch m.Value.(type) {
+	switch v := m.Value.(type) {
 	case string:
-		return &stringItem{i}
+		return &stringItem{typed[string](i)}
+	case time.Time:
+		return &timeItem{typed[time.Time](i)}
 	case bool:
-		return &boolItem{i}
+		return &boolItem{typed[bool](i)}
 	case int:
-		return &intItem{i}
+		return &intItem{typed[int](i)}
 	case int64:
-		return &int64Item{i}
+		return &int64Item{typed[int64](i)}
 	case uint:
-		return &uintItem{i}
+		return &uintItem{typed[uint](i)}
 	case uint64:
-		return &uint64Item{i}
+		return &uint64Item{typed[uint64](i)}
 	case float64:
-		return &float64Item{i}
+		return &float64Item{typed[float64](i)}
+	case json.Number:
+		f, _ := v.Float64()
+		i.Provide(f)
+		return &float64Item{typed[float64](i)}
 	case []interface{}:
 		return fromVector(i)
+	case []byte:
+		return &bytesItem{typedItem: typed[[]byte](i)}
+	case []string:
+		return &stringsItem{typed[[]string](i)}
+	case []int:
+		return &intsItem{typed[[]int](i)}
+	case []float64:
+		return &float64sItem{typed[[]float64](i)}
+	case []bool:
+		return &boolsItem{typed[[]bool](i)}
+	case int8:
+		return &int8Item{typed[int8](i)}
+	case int16:
+		return &int16Item{typed[int16](i)}
+	case int32:
+		return &int32Item{typed[int32](i)}
+	case uint8:
+		return &uint8Item{typed[uint8](i)}
+	case uint16:
+		return &uint16Item{typed[uint16](i)}
+	case uint32:
+		return &uint32Item{typed[uint32](i)}
+	case float32:
+		return &float32Item{typed[float32](i)}
+	case *big.Int:
+		return &bigIntItem{typed[*big.Int](i)}
+	case *big.Float:
+		return &bigFloatItem{typed[*big.Float](i)}
+	case *big.Rat:
+		return &decimalItem{typed[*big.Rat](i)}
+	case map[string]interface{}, map[interface{}]interface{}:
+		i.Provide(plainRaw(v))
+		return &mapItem{typed[map[string]interface{}](i)}
 	}
 
 	return i
@@ -172,7 +683,7 @@ func fromMtem(m *Mtem) Item {
 
 // json.Marshaler for this item.
 func (i *item) MarshalJSON() ([]byte, error) {
-	return json.Marshal(&Mtem{i.key, i.provided})
+	return json.Marshal(&Mtem{Key: i.key, Value: i.provided})
 }
 
 // json.Unmarshaler for this item.
@@ -183,13 +694,13 @@ func (i *item) UnmarshalJSON(b []byte) error {
 		return err
 	}
 	i.key = m.Key
-	i.provided = m.Value
+	i.Provide(m.Value)
 	return nil
 }
 
 // yaml.Marshaler for this item.
 func (i *item) MarshalYAML() (interface{}, error) {
-	return &Mtem{i.key, i.provided}, nil
+	return &Mtem{Key: i.key, Value: i.provided}, nil
 }
 
 // yaml.Unmarshaler for this item.
@@ -200,7 +711,7 @@ func (i *item) UnmarshalYAML(u func(interface{}) error) error {
 		return err
 	}
 	i.key = m.Key
-	i.provided = m.Value
+	i.Provide(m.Value)
 	return nil
 }
 
@@ -211,356 +722,1518 @@ func (i *item) Clone() Item {
 	return &ni
 }
 
+// A generic interface for an Item holding a value of type T.
+type TypedItem[T any] interface {
+	Item
+	Get() T
+	Set(T)
+}
+
+type typedItem[T any] struct {
+	Item
+}
+
+func typed[T any](i Item) *typedItem[T] {
+	return &typedItem[T]{i}
+}
+
+func newTyped[T any](key string, v T) *typedItem[T] {
+	i := KeyedItem(key)
+	i.Provide(v)
+	return typed[T](i)
+}
+
+// Creates a new TypedItem from the provided key and value.
+func NewTypedItem[T any](key string, v T) TypedItem[T] {
+	return newTyped(key, v)
+}
+
+// Creates a new TypedItem from the provided key and value, as the package
+// specific Item for the type of value where one exists, e.g. a StringItem for
+// a string.
+func NewItem[T any](key string, v T) TypedItem[T] {
+	var i Item
+	switch vv := any(v).(type) {
+	case string:
+		i = NewStringItem(key, vv)
+	case []string:
+		i = NewStringsItem(key, vv...)
+	case bool:
+		i = NewBoolItem(key, vv)
+	case int:
+		i = NewIntItem(key, vv)
+	case int64:
+		i = NewInt64Item(key, vv)
+	case uint:
+		i = NewUintItem(key, vv)
+	case uint64:
+		i = NewUint64Item(key, vv)
+	case float64:
+		i = NewFloat64Item(key, vv)
+	case time.Time:
+		i = NewTimeItem(key, vv)
+	case time.Duration:
+		i = NewDurationItem(key, vv)
+	case *Vector:
+		i = NewVectorItem(key, vv)
+	case map[string]interface{}:
+		i = NewMapItem(key, vv)
+	case []byte:
+		i = NewBytesItem(key, vv)
+	case []int:
+		i = NewIntsItem(key, vv...)
+	case []float64:
+		i = NewFloat64sItem(key, vv...)
+	case []bool:
+		i = NewBoolsItem(key, vv...)
+	case []interface{}:
+		i = NewListItem(key, vv...)
+	case int8:
+		i = NewInt8Item(key, vv)
+	case int16:
+		i = NewInt16Item(key, vv)
+	case int32:
+		i = NewInt32Item(key, vv)
+	case uint8:
+		i = NewUint8Item(key, vv)
+	case uint16:
+		i = NewUint16Item(key, vv)
+	case uint32:
+		i = NewUint32Item(key, vv)
+	case float32:
+		i = NewFloat32Item(key, vv)
+	case *big.Int:
+		i = NewBigIntItem(key, vv)
+	case *big.Float:
+		i = NewBigFloatItem(key, vv)
+	case *big.Rat:
+		i = NewDecimalItem(key, vv)
+	default:
+		return NewTypedItem(key, v)
+	}
+	return i.(TypedItem[T])
+}
+
+func typeName[T any]() string {
+	var z T
+	switch any(z).(type) {
+	case string:
+		return "string"
+	case []string:
+		return "strings"
+	case bool:
+		return "bool"
+	case int:
+		return "int"
+	case int64:
+		return "int64"
+	case uint:
+		return "uint"
+	case uint64:
+		return "uint64"
+	case float64:
+		return "float64"
+	case time.Time:
+		return "time"
+	case time.Duration:
+		return "duration"
+	case *Vector:
+		return "vector"
+	case map[string]interface{}:
+		return "map"
+	case []byte:
+		return "bytes"
+	case []int:
+		return "ints"
+	case []float64:
+		return "float64s"
+	case []bool:
+		return "bools"
+	case []interface{}:
+		return "list"
+	case int8:
+		return "int8"
+	case int16:
+		return "int16"
+	case int32:
+		return "int32"
+	case uint8:
+		return "uint8"
+	case uint16:
+		return "uint16"
+	case uint32:
+		return "uint32"
+	case float32:
+		return "float32"
+	case *big.Int:
+		return "bigint"
+	case *big.Float:
+		return "bigfloat"
+	case *big.Rat:
+		return "decimal"
+	}
+	return itemTypeNames[reflect.TypeOf((*T)(nil)).Elem()]
+}
+
+// Returns the name of the type of the provided Item as transmitted, or an
+// empty string for an Item of no named type.
+func itemTypeName(i Item) string {
+	switch t := i.(type) {
+	case NamedItem:
+		return t.TypeName()
+	case interface{ typeName() string }:
+		return t.typeName()
+	}
+	return ""
+}
+
+// An interface for an Item transmitting the name of a type registered with
+// RegisterItemType.
+type NamedItem interface {
+	Item
+	TypeName() string
+}
+
+func (i *typedItem[T]) typeName() string {
+	return typeName[T]()
+}
+
+func (i *typedItem[T]) mtem() *Mtem {
+	var v interface{} = i.Provided()
+	if _, ok := v.(T); !ok {
+		v = i.Get()
+	}
+	return &Mtem{Key: i.Key(), Value: v, Type: i.typeName()}
+}
+
+// json.Marshaler for this TypedItem, transmitting its type name.
+func (i *typedItem[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.mtem())
+}
+
+// json.Unmarshaler for this TypedItem, converting the transmitted value to T
+// where possible.
+func (i *typedItem[T]) UnmarshalJSON(b []byte) error {
+	var m Mtem
+	if err := decodeJSON(b, &m); err != nil {
+		return err
+	}
+	i.fromMtem(&m)
+	return nil
+}
+
+// yaml.Marshaler for this TypedItem, transmitting its type name.
+func (i *typedItem[T]) MarshalYAML() (interface{}, error) {
+	return i.mtem(), nil
+}
+
+// yaml.Unmarshaler for this TypedItem, converting the transmitted value to T
+// where possible.
+func (i *typedItem[T]) UnmarshalYAML(u func(interface{}) error) error {
+	var m Mtem
+	if err := u(&m); err != nil {
+		return err
+	}
+	i.fromMtem(&m)
+	return nil
+}
+
+func (i *typedItem[T]) fromMtem(m *Mtem) {
+	i.NewKey(m.Key)
+	if m.Type == "" {
+		m.Type = i.typeName()
+	}
+	if ni, err := fromMtem(m); err == nil {
+		if p, ok := ni.Provided().(T); ok {
+			i.Provide(p)
+			return
+		}
+	}
+	i.Provide(m.Value)
+}
+
+// Returns the held value. A value provided as another type, e.g. by
+// unmarshaling, is converted through its json encoding.
+func (i *typedItem[T]) Get() T {
+	if r, ok := i.Provided().(T); ok {
+		return r
+	}
+	var r T
+	json.Unmarshal(i.Value(), &r)
+	return r
+}
+
+// Sets the held value.
+func (i *typedItem[T]) Set(v T) {
+	i.Provide(v)
+}
+
+func (i *typedItem[T]) clone() *typedItem[T] {
+	return typed[T](i.Item.Clone())
+}
+
+//
+func (i *typedItem[T]) Clone() Item {
+	return i.clone()
+}
+
 // An interface for a specific string type Item.
 type StringItem interface {
-	Item
+	TypedItem[string]
 	ToString() string
 	SetString(string)
 }
 
 type stringItem struct {
-	Item
+	*typedItem[string]
 }
 
 // Creates a new StringItem from the provided key and value.
 func NewStringItem(key, v string) StringItem {
-	i := KeyedItem(key)
-	i.Provide(v)
-	return &stringItem{i}
+	return &stringItem{newTyped(key, v)}
 }
 
 // Returns the string value of this StringItem.
 func (i *stringItem) ToString() string {
-	var ret string
-	err := json.Unmarshal(i.Value(), &ret)
-	if err != nil {
-		return err.Error()
-	}
-	return ret
+	return i.Get()
 }
 
 // Sets a string value for this StringItem.
 func (i *stringItem) SetString(s string) {
-	i.Provide(s)
+	i.Set(s)
 }
 
 // Satisfies the Cloner interface for this StringItem.
 func (i *stringItem) Clone() Item {
-	ii := i.Item.Clone()
-	return &stringItem{ii}
+	return &stringItem{i.clone()}
+}
+
+// An interface for an Item aliasing the Item held at another key, the key of
+// which it holds as a string. A RefItem is held and transmitted as such, and
+// followed as read by Get or any ToX method to the Item it aliases.
+type RefItem interface {
+	TypedItem[string]
+	Ref() string
+}
+
+type refItem struct {
+	*typedItem[string]
+}
+
+// Creates a new RefItem from the provided key, aliasing the provided key.
+func NewRefItem(key, ref string) RefItem {
+	return &refItem{newTyped(key, ref)}
+}
+
+// Returns the key aliased by this RefItem.
+func (i *refItem) Ref() string {
+	return i.Get()
+}
+
+func (i *refItem) typeName() string {
+	return "ref"
+}
+
+// json.Marshaler for this RefItem.
+func (i *refItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&Mtem{Key: i.Key(), Value: i.Get(), Type: i.typeName()})
+}
+
+// yaml.Marshaler for this RefItem.
+func (i *refItem) MarshalYAML() (interface{}, error) {
+	return &Mtem{Key: i.Key(), Value: i.Get(), Type: i.typeName()}, nil
+}
+
+//
+func (i *refItem) Clone() Item {
+	return &refItem{i.clone()}
 }
 
 // An interface for a specific []string type Item.
 type StringsItem interface {
-	Item
+	TypedItem[[]string]
 	ToStrings() []string
 	SetStrings(...string)
 }
 
 type stringsItem struct {
-	Item
+	*typedItem[[]string]
 }
 
 // Creates a new StringsItem from the provided key and string values.
 func NewStringsItem(key string, v ...string) StringsItem {
-	i := KeyedItem(key)
-	i.Provide(v)
-	return &stringsItem{i}
+	return &stringsItem{newTyped(key, v)}
 }
 
 //
 func (i *stringsItem) ToStrings() []string {
-	var ret []string
-	err := json.Unmarshal(i.Value(), &ret)
-	if err != nil {
-		return []string{err.Error()}
-	}
-	return ret
+	return i.Get()
 }
 
 //
 func (i *stringsItem) SetStrings(l ...string) {
-	i.Provide(l)
+	i.Set(l)
 }
 
 //
 func (i *stringsItem) Clone() Item {
-	ii := i.Item.Clone()
-	return &stringsItem{ii}
+	return &stringsItem{i.clone()}
+}
+
+// An interface for a specific []int type Item.
+type IntsItem interface {
+	TypedItem[[]int]
+	ToInts() []int
+	SetInts(...int)
+}
+
+type intsItem struct {
+	*typedItem[[]int]
+}
+
+// Creates a new IntsItem from the provided key and int values.
+func NewIntsItem(key string, v ...int) IntsItem {
+	return &intsItem{newTyped(key, v)}
+}
+
+//
+func (i *intsItem) ToInts() []int {
+	return i.Get()
+}
+
+//
+func (i *intsItem) SetInts(l ...int) {
+	i.Set(l)
+}
+
+//
+func (i *intsItem) Clone() Item {
+	return &intsItem{i.clone()}
+}
+
+// An interface for a specific []float64 type Item.
+type Float64sItem interface {
+	TypedItem[[]float64]
+	ToFloat64s() []float64
+	SetFloat64s(...float64)
+}
+
+type float64sItem struct {
+	*typedItem[[]float64]
+}
+
+// Creates a new Float64sItem from the provided key and float64 values.
+func NewFloat64sItem(key string, v ...float64) Float64sItem {
+	return &float64sItem{newTyped(key, v)}
+}
+
+//
+func (i *float64sItem) ToFloat64s() []float64 {
+	return i.Get()
+}
+
+//
+func (i *float64sItem) SetFloat64s(l ...float64) {
+	i.Set(l)
+}
+
+//
+func (i *float64sItem) Clone() Item {
+	return &float64sItem{i.clone()}
+}
+
+// An interface for a specific []bool type Item.
+type BoolsItem interface {
+	TypedItem[[]bool]
+	ToBools() []bool
+	SetBools(...bool)
+}
+
+type boolsItem struct {
+	*typedItem[[]bool]
+}
+
+// Creates a new BoolsItem from the provided key and bool values.
+func NewBoolsItem(key string, v ...bool) BoolsItem {
+	return &boolsItem{newTyped(key, v)}
+}
+
+//
+func (i *boolsItem) ToBools() []bool {
+	return i.Get()
+}
+
+//
+func (i *boolsItem) SetBools(l ...bool) {
+	i.Set(l)
+}
+
+//
+func (i *boolsItem) Clone() Item {
+	return &boolsItem{i.clone()}
+}
+
+// An interface for a specific []interface{} type Item, holding values of any
+// type. A ListItem transmits each value as an Item keyed by its index, so that
+// the type of every value is kept.
+type ListItem interface {
+	TypedItem[[]interface{}]
+	ToList() []interface{}
+	SetList(...interface{})
+}
+
+type listItem struct {
+	*typedItem[[]interface{}]
+}
+
+// Creates a new ListItem from the provided key and values.
+func NewListItem(key string, v ...interface{}) ListItem {
+	return &listItem{newTyped(key, v)}
+}
+
+//
+func (i *listItem) ToList() []interface{} {
+	return i.Get()
+}
+
+//
+func (i *listItem) SetList(l ...interface{}) {
+	i.Set(l)
+}
+
+func (i *listItem) items() []Item {
+	l := i.Get()
+	ret := make([]Item, 0, len(l))
+	for n, e := range l {
+		ret = append(ret, valueItem(strconv.Itoa(n), e))
+	}
+	return ret
+}
+
+// json.Marshaler for this ListItem.
+func (i *listItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&Mtem{Key: i.Key(), Value: i.items(), Type: i.typeName()})
+}
+
+// yaml.Marshaler for this ListItem.
+func (i *listItem) MarshalYAML() (interface{}, error) {
+	return &Mtem{Key: i.Key(), Value: i.items(), Type: i.typeName()}, nil
+}
+
+//
+func (i *listItem) Clone() Item {
+	return &listItem{i.clone()}
 }
 
 // An interface for a specific bool type Item.
 type BoolItem interface {
-	Item
+	TypedItem[bool]
 	ToBool() bool
 	SetBool(bool)
 }
 
 type boolItem struct {
-	Item
+	*typedItem[bool]
 }
 
 // Creates a new BoolItem from the provided string key and boolean value.
 func NewBoolItem(key string, v bool) BoolItem {
-	i := KeyedItem(key)
-	i.Provide(v)
-	return &boolItem{i}
+	return &boolItem{newTyped(key, v)}
 }
 
 //
 func (i *boolItem) ToBool() bool {
-	var ret bool
-	err := json.Unmarshal(i.Value(), &ret)
-	if err != nil {
-		return false
-	}
-	return ret
+	return i.Get()
 }
 
 //
 func (i *boolItem) SetBool(v bool) {
-	i.Provide(v)
+	i.Set(v)
 }
 
 //
 func (i *boolItem) Clone() Item {
-	ii := i.Item.Clone()
-	return &boolItem{ii}
+	return &boolItem{i.clone()}
 }
 
 // An interface for a specific int type Item.
 type IntItem interface {
-	Item
+	TypedItem[int]
 	ToInt() int
 	SetInt(int)
 }
 
 type intItem struct {
-	Item
+	*typedItem[int]
 }
 
 // Creates a new IntItem from the provided string key and int value.
 func NewIntItem(key string, v int) IntItem {
-	i := KeyedItem(key)
-	i.Provide(v)
-	return &intItem{i}
+	return &intItem{newTyped(key, v)}
 }
 
 //
 func (i *intItem) ToInt() int {
-	var ret int
-	err := json.Unmarshal(i.Value(), &ret)
-	if err != nil {
-		return 0
-	}
-	return ret
+	return i.Get()
 }
 
 //
 func (i *intItem) SetInt(v int) {
-	i.Provide(v)
+	i.Set(v)
 }
 
 //
 func (i *intItem) Clone() Item {
-	ii := i.Item.Clone()
-	return &intItem{ii}
+	return &intItem{i.clone()}
 }
 
 // An interface for a specific int64 type Item.
 type Int64Item interface {
-	Item
+	TypedItem[int64]
 	ToInt64() int64
-	SetInt64(int)
+	SetInt64(int64)
 }
 
 type int64Item struct {
-	Item
+	*typedItem[int64]
 }
 
 // Creates a new Int64Item from the provided string key and int64 value.
 func NewInt64Item(key string, v int64) Int64Item {
-	i := KeyedItem(key)
-	i.Provide(v)
-	return &int64Item{i}
+	return &int64Item{newTyped(key, v)}
 }
 
 //
 func (i *int64Item) ToInt64() int64 {
-	var ret int64
-	err := json.Unmarshal(i.Value(), &ret)
-	if err != nil {
-		return 0
-	}
-	return ret
+	return i.Get()
 }
 
 //
-func (i *int64Item) SetInt64(v int) {
-	i.Provide(v)
+func (i *int64Item) SetInt64(v int64) {
+	i.Set(v)
 }
 
 //
 func (i *int64Item) Clone() Item {
-	ii := i.Item.Clone()
-	return &int64Item{ii}
+	return &int64Item{i.clone()}
 }
 
 // An interface for a specific uint type Item.
 type UintItem interface {
-	Item
+	TypedItem[uint]
 	ToUint() uint
 	SetUint(uint)
 }
 
 type uintItem struct {
-	Item
+	*typedItem[uint]
 }
 
 // Creates a new UintItem from the provided string key and uint value.
 func NewUintItem(key string, v uint) UintItem {
-	i := KeyedItem(key)
-	i.Provide(v)
-	return &uintItem{i}
+	return &uintItem{newTyped(key, v)}
 }
 
 //
 func (i *uintItem) ToUint() uint {
-	var ret uint
-	err := json.Unmarshal(i.Value(), &ret)
-	if err != nil {
-		return 0
-	}
-	return ret
+	return i.Get()
 }
 
 //
 func (i *uintItem) SetUint(v uint) {
-	i.Provide(v)
+	i.Set(v)
 }
 
 //
 func (i *uintItem) Clone() Item {
-	ii := i.Item.Clone()
-	return &uintItem{ii}
+	return &uintItem{i.clone()}
 }
 
 // An interface for a specific uint64 type Item.
 type Uint64Item interface {
-	Item
+	TypedItem[uint64]
 	ToUint64() uint64
 	SetUint64(uint64)
 }
 
 type uint64Item struct {
-	Item
+	*typedItem[uint64]
 }
 
 // Creates a new Uint64Item from the provided string key and uint64 value.
 func NewUint64Item(key string, v uint64) Uint64Item {
-	i := KeyedItem(key)
-	i.Provide(v)
-	return &uint64Item{i}
+	return &uint64Item{newTyped(key, v)}
 }
 
 //
 func (i *uint64Item) ToUint64() uint64 {
-	var ret uint64
-	err := json.Unmarshal(i.Value(), &ret)
-	if err != nil {
-		return 0
-	}
-	return ret
+	return i.Get()
 }
 
 //
 func (i *uint64Item) SetUint64(v uint64) {
-	i.Provide(v)
+	i.Set(v)
 }
 
 //
 func (i *uint64Item) Clone() Item {
-	ii := i.Item.Clone()
-	return &uint64Item{ii}
+	return &uint64Item{i.clone()}
 }
 
 // An interface for a specific float64 type Item.
 type Float64Item interface {
-	Item
+	TypedItem[float64]
 	ToFloat64() float64
 	SetFloat(float64)
 }
 
 type float64Item struct {
-	Item
+	*typedItem[float64]
 }
 
 // Creates a new Float64Item from the provided string key and float64 value.
 func NewFloat64Item(key string, v float64) Float64Item {
-	i := KeyedItem(key)
-	i.Provide(v)
-	return &float64Item{i}
+	return &float64Item{newTyped(key, v)}
 }
 
 //
 func (i *float64Item) ToFloat64() float64 {
-	var ret float64
-	err := json.Unmarshal(i.Value(), &ret)
-	if err != nil {
-		return 0
-	}
-	return ret
+	return i.Get()
 }
 
 //
 func (i *float64Item) SetFloat(v float64) {
-	i.Provide(v)
+	i.Set(v)
 }
 
 //
 func (i *float64Item) Clone() Item {
-	ii := i.Item.Clone()
-	return &float64Item{ii}
+	return &float64Item{i.clone()}
+}
+
+// An interface for a specific int8 type Item.
+type Int8Item interface {
+	TypedItem[int8]
+	ToInt8() int8
+	SetInt8(int8)
+}
+
+type int8Item struct {
+	*typedItem[int8]
+}
+
+// Creates a new Int8Item from the provided string key and int8 value.
+func NewInt8Item(key string, v int8) Int8Item {
+	return &int8Item{newTyped(key, v)}
+}
+
+//
+func (i *int8Item) ToInt8() int8 {
+	return i.Get()
+}
+
+//
+func (i *int8Item) SetInt8(v int8) {
+	i.Set(v)
+}
+
+//
+func (i *int8Item) Clone() Item {
+	return &int8Item{i.clone()}
+}
+
+// An interface for a specific int16 type Item.
+type Int16Item interface {
+	TypedItem[int16]
+	ToInt16() int16
+	SetInt16(int16)
+}
+
+type int16Item struct {
+	*typedItem[int16]
+}
+
+// Creates a new Int16Item from the provided string key and int16 value.
+func NewInt16Item(key string, v int16) Int16Item {
+	return &int16Item{newTyped(key, v)}
+}
+
+//
+func (i *int16Item) ToInt16() int16 {
+	return i.Get()
+}
+
+//
+func (i *int16Item) SetInt16(v int16) {
+	i.Set(v)
+}
+
+//
+func (i *int16Item) Clone() Item {
+	return &int16Item{i.clone()}
+}
+
+// An interface for a specific int32 type Item.
+type Int32Item interface {
+	TypedItem[int32]
+	ToInt32() int32
+	SetInt32(int32)
+}
+
+type int32Item struct {
+	*typedItem[int32]
+}
+
+// Creates a new Int32Item from the provided string key and int32 value.
+func NewInt32Item(key string, v int32) Int32Item {
+	return &int32Item{newTyped(key, v)}
+}
+
+//
+func (i *int32Item) ToInt32() int32 {
+	return i.Get()
+}
+
+//
+func (i *int32Item) SetInt32(v int32) {
+	i.Set(v)
+}
+
+//
+func (i *int32Item) Clone() Item {
+	return &int32Item{i.clone()}
+}
+
+// An interface for a specific uint8 type Item.
+type Uint8Item interface {
+	TypedItem[uint8]
+	ToUint8() uint8
+	SetUint8(uint8)
+}
+
+type uint8Item struct {
+	*typedItem[uint8]
+}
+
+// Creates a new Uint8Item from the provided string key and uint8 value.
+func NewUint8Item(key string, v uint8) Uint8Item {
+	return &uint8Item{newTyped(key, v)}
+}
+
+//
+func (i *uint8Item) ToUint8() uint8 {
+	return i.Get()
+}
+
+//
+func (i *uint8Item) SetUint8(v uint8) {
+	i.Set(v)
+}
+
+//
+func (i *uint8Item) Clone() Item {
+	return &uint8Item{i.clone()}
+}
+
+// An interface for a specific uint16 type Item.
+type Uint16Item interface {
+	TypedItem[uint16]
+	ToUint16() uint16
+	SetUint16(uint16)
+}
+
+type uint16Item struct {
+	*typedItem[uint16]
+}
+
+// Creates a new Uint16Item from the provided string key and uint16 value.
+func NewUint16Item(key string, v uint16) Uint16Item {
+	return &uint16Item{newTyped(key, v)}
+}
+
+//
+func (i *uint16Item) ToUint16() uint16 {
+	return i.Get()
+}
+
+//
+func (i *uint16Item) SetUint16(v uint16) {
+	i.Set(v)
+}
+
+//
+func (i *uint16Item) Clone() Item {
+	return &uint16Item{i.clone()}
+}
+
+// An interface for a specific uint32 type Item.
+type Uint32Item interface {
+	TypedItem[uint32]
+	ToUint32() uint32
+	SetUint32(uint32)
+}
+
+type uint32Item struct {
+	*typedItem[uint32]
+}
+
+// Creates a new Uint32Item from the provided string key and uint32 value.
+func NewUint32Item(key string, v uint32) Uint32Item {
+	return &uint32Item{newTyped(key, v)}
+}
+
+//
+func (i *uint32Item) ToUint32() uint32 {
+	return i.Get()
+}
+
+//
+func (i *uint32Item) SetUint32(v uint32) {
+	i.Set(v)
+}
+
+//
+func (i *uint32Item) Clone() Item {
+	return &uint32Item{i.clone()}
+}
+
+// An interface for a specific float32 type Item.
+type Float32Item interface {
+	TypedItem[float32]
+	ToFloat32() float32
+	SetFloat32(float32)
+}
+
+type float32Item struct {
+	*typedItem[float32]
+}
+
+// Creates a new Float32Item from the provided string key and float32 value.
+func NewFloat32Item(key string, v float32) Float32Item {
+	return &float32Item{newTyped(key, v)}
+}
+
+//
+func (i *float32Item) ToFloat32() float32 {
+	return i.Get()
+}
+
+//
+func (i *float32Item) SetFloat32(v float32) {
+	i.Set(v)
+}
+
+//
+func (i *float32Item) Clone() Item {
+	return &float32Item{i.clone()}
+}
+
+// An interface for a specific *big.Int type Item. A BigIntItem holds and
+// returns copies, and transmits as a decimal string.
+type BigIntItem interface {
+	TypedItem[*big.Int]
+	ToBigInt() *big.Int
+	SetBigInt(*big.Int)
+}
+
+type bigIntItem struct {
+	*typedItem[*big.Int]
+}
+
+// Creates a new BigIntItem from the provided string key and *big.Int value.
+func NewBigIntItem(key string, v *big.Int) BigIntItem {
+	i := &bigIntItem{typed[*big.Int](KeyedItem(key))}
+	i.Set(v)
+	return i
+}
+
+// Returns a copy of the held value.
+func (i *bigIntItem) Get() *big.Int {
+	switch v := i.Provided().(type) {
+	case *big.Int:
+		if v != nil {
+			return new(big.Int).Set(v)
+		}
+	case string:
+		if r, ok := new(big.Int).SetString(v, 10); ok {
+			return r
+		}
+	}
+	return nil
+}
+
+// Holds a copy of the provided value.
+func (i *bigIntItem) Set(v *big.Int) {
+	if v != nil {
+		v = new(big.Int).Set(v)
+	}
+	i.Provide(v)
+}
+
+//
+func (i *bigIntItem) ToBigInt() *big.Int {
+	return i.Get()
+}
+
+//
+func (i *bigIntItem) SetBigInt(v *big.Int) {
+	i.Set(v)
+}
+
+func (i *bigIntItem) text() interface{} {
+	if v := i.Get(); v != nil {
+		return v.String()
+	}
+	return nil
+}
+
+// Returns the held value as transmitted, a json string.
+func (i *bigIntItem) Value() []byte {
+	b, _ := json.Marshal(i.text())
+	return b
+}
+
+// json.Marshaler for this BigIntItem.
+func (i *bigIntItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&Mtem{Key: i.Key(), Value: i.text(), Type: i.typeName()})
+}
+
+// yaml.Marshaler for this BigIntItem.
+func (i *bigIntItem) MarshalYAML() (interface{}, error) {
+	return &Mtem{Key: i.Key(), Value: i.text(), Type: i.typeName()}, nil
+}
+
+//
+func (i *bigIntItem) Clone() Item {
+	return &bigIntItem{i.clone()}
+}
+
+// An interface for a specific *big.Float type Item. A BigFloatItem holds and
+// returns copies, and transmits as a decimal string restoring its exact
+// value, though not its precision.
+type BigFloatItem interface {
+	TypedItem[*big.Float]
+	ToBigFloat() *big.Float
+	SetBigFloat(*big.Float)
+}
+
+type bigFloatItem struct {
+	*typedItem[*big.Float]
+}
+
+// Creates a new BigFloatItem from the provided string key and *big.Float
+// value.
+func NewBigFloatItem(key string, v *big.Float) BigFloatItem {
+	i := &bigFloatItem{typed[*big.Float](KeyedItem(key))}
+	i.Set(v)
+	return i
+}
+
+// Parses a decimal string as a *big.Float with the precision needed for its
+// digits, and at least the 64 bits of a default big.Float.
+func parseBigFloat(s string) (*big.Float, error) {
+	var digits int
+	for _, r := range s {
+		if r == 'e' || r == 'E' {
+			break
+		}
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	prec := uint(math.Ceil(float64(digits) * math.Log2(10)))
+	if prec < 64 {
+		prec = 64
+	}
+	f, _, err := big.ParseFloat(s, 10, prec, big.ToNearestEven)
+	return f, err
+}
+
+// Returns a copy of the held value.
+func (i *bigFloatItem) Get() *big.Float {
+	switch v := i.Provided().(type) {
+	case *big.Float:
+		if v != nil {
+			return new(big.Float).Copy(v)
+		}
+	case string:
+		if r, err := parseBigFloat(v); err == nil {
+			return r
+		}
+	}
+	return nil
+}
+
+// Holds a copy of the provided value.
+func (i *bigFloatItem) Set(v *big.Float) {
+	if v != nil {
+		v = new(big.Float).Copy(v)
+	}
+	i.Provide(v)
+}
+
+//
+func (i *bigFloatItem) ToBigFloat() *big.Float {
+	return i.Get()
+}
+
+//
+func (i *bigFloatItem) SetBigFloat(v *big.Float) {
+	i.Set(v)
+}
+
+// Returns the shortest decimal string of the held value where it restores
+// the value exactly, otherwise the exact decimal string.
+func (i *bigFloatItem) text() interface{} {
+	v := i.Get()
+	if v == nil {
+		return nil
+	}
+	s := v.Text('g', -1)
+	if r, err := parseBigFloat(s); err == nil && r.Cmp(v) == 0 {
+		return s
+	}
+	e := v.MantExp(nil)
+	if e < 0 {
+		e = -e
+	}
+	return v.Text('g', int(v.MinPrec())+e+2)
+}
+
+// Returns the held value as transmitted, a json string.
+func (i *bigFloatItem) Value() []byte {
+	b, _ := json.Marshal(i.text())
+	return b
+}
+
+// json.Marshaler for this BigFloatItem.
+func (i *bigFloatItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&Mtem{Key: i.Key(), Value: i.text(), Type: i.typeName()})
+}
+
+// yaml.Marshaler for this BigFloatItem.
+func (i *bigFloatItem) MarshalYAML() (interface{}, error) {
+	return &Mtem{Key: i.Key(), Value: i.text(), Type: i.typeName()}, nil
+}
+
+//
+func (i *bigFloatItem) Clone() Item {
+	return &bigFloatItem{i.clone()}
+}
+
+// An interface for a specific decimal type Item, holding an exact *big.Rat.
+// A DecimalItem holds and returns copies, and transmits as a decimal string,
+// e.g. "10.25", or as a fraction, e.g. "1/3", for a value with no finite
+// decimal form, so that no precision is ever lost.
+type DecimalItem interface {
+	TypedItem[*big.Rat]
+	ToDecimal() *big.Rat
+	SetDecimal(*big.Rat)
+}
+
+type decimalItem struct {
+	*typedItem[*big.Rat]
+}
+
+// Creates a new DecimalItem from the provided string key and *big.Rat value.
+func NewDecimalItem(key string, v *big.Rat) DecimalItem {
+	i := &decimalItem{typed[*big.Rat](KeyedItem(key))}
+	i.Set(v)
+	return i
+}
+
+// Returns a *big.Rat parsed from a decimal or fraction string.
+func ParseDecimal(s string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, ConversionError(s, "decimal")
+	}
+	return r, nil
+}
+
+// Returns the exact decimal string of the provided value, or its fraction
+// string where it has no finite decimal form.
+func decimalString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	d := new(big.Int).Set(r.Denom())
+	var scale int
+	for _, f := range []int64{2, 5} {
+		bf, m := big.NewInt(f), new(big.Int)
+		n := 0
+		for {
+			q, rem := new(big.Int).QuoRem(d, bf, m)
+			if rem.Sign() != 0 {
+				break
+			}
+			d, n = q, n+1
+		}
+		if n > scale {
+			scale = n
+		}
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return r.String()
+	}
+	return r.FloatString(scale)
+}
+
+// Returns a copy of the held value.
+func (i *decimalItem) Get() *big.Rat {
+	switch v := i.Provided().(type) {
+	case *big.Rat:
+		if v != nil {
+			return new(big.Rat).Set(v)
+		}
+	case string:
+		if r, err := ParseDecimal(v); err == nil {
+			return r
+		}
+	}
+	return nil
+}
+
+// Holds a copy of the provided value.
+func (i *decimalItem) Set(v *big.Rat) {
+	if v != nil {
+		v = new(big.Rat).Set(v)
+	}
+	i.Provide(v)
+}
+
+//
+func (i *decimalItem) ToDecimal() *big.Rat {
+	return i.Get()
+}
+
+//
+func (i *decimalItem) SetDecimal(v *big.Rat) {
+	i.Set(v)
+}
+
+func (i *decimalItem) text() interface{} {
+	if v := i.Get(); v != nil {
+		return decimalString(v)
+	}
+	return nil
+}
+
+// Returns the held value as transmitted, a json string.
+func (i *decimalItem) Value() []byte {
+	b, _ := json.Marshal(i.text())
+	return b
+}
+
+// json.Marshaler for this DecimalItem.
+func (i *decimalItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&Mtem{Key: i.Key(), Value: i.text(), Type: i.typeName()})
+}
+
+// yaml.Marshaler for this DecimalItem.
+func (i *decimalItem) MarshalYAML() (interface{}, error) {
+	return &Mtem{Key: i.Key(), Value: i.text(), Type: i.typeName()}, nil
+}
+
+//
+func (i *decimalItem) Clone() Item {
+	return &decimalItem{i.clone()}
+}
+
+// An interface for a specific time.Time type Item.
+type TimeItem interface {
+	TypedItem[time.Time]
+	ToTime() time.Time
+	SetTime(time.Time)
+}
+
+type timeItem struct {
+	*typedItem[time.Time]
+}
+
+// Creates a new TimeItem from the provided string key and time.Time value.
+func NewTimeItem(key string, v time.Time) TimeItem {
+	return &timeItem{newTyped(key, v)}
+}
+
+//
+func (i *timeItem) ToTime() time.Time {
+	return i.Get()
+}
+
+//
+func (i *timeItem) SetTime(v time.Time) {
+	i.Set(v)
+}
+
+//
+func (i *timeItem) Clone() Item {
+	return &timeItem{i.clone()}
+}
+
+// An interface for a specific time.Duration type Item. A DurationItem
+// transmits as a duration string, e.g. "1m30s".
+type DurationItem interface {
+	TypedItem[time.Duration]
+	ToDuration() time.Duration
+	SetDuration(time.Duration)
+}
+
+type durationItem struct {
+	*typedItem[time.Duration]
+}
+
+// Creates a new DurationItem from the provided string key and time.Duration
+// value.
+func NewDurationItem(key string, v time.Duration) DurationItem {
+	return &durationItem{newTyped(key, v)}
+}
+
+// Returns the held value, parsing a held duration string.
+func (i *durationItem) Get() time.Duration {
+	if s, ok := i.Provided().(string); ok {
+		d, _ := time.ParseDuration(s)
+		return d
+	}
+	return i.typedItem.Get()
+}
+
+//
+func (i *durationItem) ToDuration() time.Duration {
+	return i.Get()
+}
+
+//
+func (i *durationItem) SetDuration(v time.Duration) {
+	i.Set(v)
+}
+
+// json.Marshaler for this DurationItem.
+func (i *durationItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&Mtem{Key: i.Key(), Value: i.Get().String(), Type: i.typeName()})
+}
+
+// yaml.Marshaler for this DurationItem.
+func (i *durationItem) MarshalYAML() (interface{}, error) {
+	return &Mtem{Key: i.Key(), Value: i.Get().String(), Type: i.typeName()}, nil
+}
+
+//
+func (i *durationItem) Clone() Item {
+	return &durationItem{i.clone()}
 }
 
 // An interface for a specific Vector type Item, i.e store multiple vectors
 // within a single vector.
 type VectorItem interface {
-	Item
+	TypedItem[*Vector]
 	ToVector() *Vector
 	SetVector(*Vector)
 }
 
 type vectorItem struct {
-	Item
+	*typedItem[*Vector]
 }
 
 // Creates a new VectorItem from the provided string key and *Vector value.
+// The VectorItem holds a copy of the Vector as of this call.
 func NewVectorItem(key string, v *Vector) Item {
-	i := KeyedItem(key)
+	i := &vectorItem{typed[*Vector](KeyedItem(key))}
+	i.Set(v)
+	return i
+}
+
+// Returns the held Vector, to be read but not changed; see ToVector.
+func (i *vectorItem) Get() *Vector {
+	return i.typedItem.Get()
+}
+
+// Holds a copy of the provided Vector.
+func (i *vectorItem) Set(v *Vector) {
+	if v != nil {
+		v = v.Snapshot().Vector()
+	}
 	i.Provide(v)
-	return &vectorItem{i}
 }
 
-//
+// Returns a copy of the held Vector, sharing structure until either is
+// changed, or the held Vector itself where frozen.
 func (i *vectorItem) ToVector() *Vector {
-	ret := New("")
-	err := json.Unmarshal(i.Value(), &ret)
-	if err != nil {
-		return nil
+	v := i.Get()
+	if v == nil || v.Frozen() {
+		return v
 	}
-	return ret
+	return v.Snapshot().Vector()
 }
 
 //
 func (i *vectorItem) SetVector(v *Vector) {
+	i.Set(v)
+}
+
+// Satisfies the Cloner interface for this VectorItem, the copy holding a copy
+// of the Vector, sharing structure until either is changed.
+func (i *vectorItem) Clone() Item {
+	c := &vectorItem{i.clone()}
+	c.Set(i.Get())
+	return c
+}
+
+// An interface for a specific map[string]interface{} type Item, e.g. an object
+// decoded from json or yaml.
+type MapItem interface {
+	TypedItem[map[string]interface{}]
+	ToMap() map[string]interface{}
+	SetMap(map[string]interface{})
+}
+
+type mapItem struct {
+	*typedItem[map[string]interface{}]
+}
+
+// Creates a new MapItem from the provided string key and map value.
+func NewMapItem(key string, v map[string]interface{}) MapItem {
+	return &mapItem{newTyped(key, v)}
+}
+
+//
+func (i *mapItem) ToMap() map[string]interface{} {
+	return i.Get()
+}
+
+//
+func (i *mapItem) SetMap(v map[string]interface{}) {
+	i.Set(v)
+}
+
+//
+func (i *mapItem) Clone() Item {
+	return &mapItem{i.clone()}
+}
+
+// An interface for a specific []byte type Item, e.g. a certificate, image, or
+// hash. A BytesItem transmits as base64 in json, and in yaml as a !!binary
+// scalar.
+type BytesItem interface {
+	TypedItem[[]byte]
+	ToBytes() []byte
+	SetBytes([]byte)
+	Open() (io.ReadCloser, error)
+}
+
+type bytesItem struct {
+	*typedItem[[]byte]
+	open func() (io.ReadCloser, error)
+}
+
+// Creates a new BytesItem from the provided string key and []byte value.
+func NewBytesItem(key string, v []byte) BytesItem {
+	return &bytesItem{typedItem: newTyped(key, v)}
+}
+
+// Creates a new BytesItem from the provided string key, with a value read in
+// full from a reader returned by the provided function each time it is
+// needed. The value is not held in memory, and is streamed by EncodeJSON.
+func NewBytesItemFrom(key string, open func() (io.ReadCloser, error)) BytesItem {
+	return &bytesItem{typed[[]byte](KeyedItem(key)), open}
+}
+
+// Returns a reader of the held value.
+func (i *bytesItem) Open() (io.ReadCloser, error) {
+	if i.open != nil {
+		return i.open()
+	}
+	return io.NopCloser(bytes.NewReader(i.Get())), nil
+}
+
+// Returns the held value, reading it in full where the BytesItem was created
+// by NewBytesItemFrom, or nil where it fails to read.
+func (i *bytesItem) Get() []byte {
+	b, _ := i.read()
+	return b
+}
+
+// Returns the held value, or any error opening or reading it.
+func (i *bytesItem) read() ([]byte, error) {
+	if i.open == nil {
+		return i.typedItem.Get(), nil
+	}
+	r, err := i.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// Holds the provided value, replacing any reader.
+func (i *bytesItem) Set(v []byte) {
+	i.open = nil
 	i.Provide(v)
 }
 
+// Returns the held value, unencoded.
+func (i *bytesItem) Value() []byte {
+	return i.Get()
+}
+
 //
-func (i *vectorItem) Clone() Item {
-	c := i.ToVector()
-	ii := i.Item.Clone()
-	ii.Provide(c)
-	return &vectorItem{ii}
+func (i *bytesItem) ToBytes() []byte {
+	return i.Get()
+}
+
+//
+func (i *bytesItem) SetBytes(v []byte) {
+	i.Set(v)
+}
+
+// json.Marshaler for this BytesItem.
+func (i *bytesItem) MarshalJSON() ([]byte, error) {
+	b, err := i.read()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&Mtem{Key: i.Key(), Value: b, Type: i.typeName()})
+}
+
+// Writes this BytesItem as json to w, streaming the base64 encoded value.
+func (i *bytesItem) encodeJSON(w io.Writer) error {
+	k, err := json.Marshal(i.Key())
+	if err != nil {
+		return err
+	}
+	r, err := i.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	fmt.Fprintf(w, `{"key":%s,"value":"`, k)
+	e := base64.NewEncoder(base64.StdEncoding, w)
+	if _, err := io.Copy(e, r); err != nil {
+		return err
+	}
+	if err := e.Close(); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, `","type":%q}`, i.typeName())
+	return err
+}
+
+// yaml.Marshaler for this BytesItem, transmitting the value as !!binary.
+func (i *bytesItem) MarshalYAML() (interface{}, error) {
+	b, err := i.read()
+	if err != nil {
+		return nil, err
+	}
+	n := &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   "!!binary",
+		Value: base64.StdEncoding.EncodeToString(b),
+	}
+	return &Mtem{Key: i.Key(), Value: n, Type: "binary"}, nil
+}
+
+//
+func (i *bytesItem) Clone() Item {
+	return &bytesItem{i.clone(), i.open}
 }
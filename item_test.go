@@ -1,10 +1,19 @@
 package data
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"math/big"
+	"net"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
-	yaml "gopkg.in/yaml.v2"
+	yaml "gopkg.in/yaml.v3"
 )
 
 func TestItem(t *testing.T) {
@@ -204,5 +213,415 @@ func TestVectorItem(t *testing.T) {
 		if ci != "ONE" {
 			t.Errorf("multi item container item is not 'ONE': %s", ci)
 		}
+		if i.Get() != i.Get() {
+			t.Error("reading a vector item copied the held vector")
+		}
+		v1.SetString("vector.1", "CHANGED")
+		if i.ToVector() == i.Get() || i.Get().ToString("vector.1") != "ONE" {
+			t.Error("change to a vector read from a vector item changed the held vector")
+		}
+		c := i.Clone().(VectorItem)
+		c.Get().SetString("vector.1", "CHANGED")
+		if i.Get().ToString("vector.1") != "ONE" {
+			t.Error("change to a cloned vector item changed the original")
+		}
+	}
+
+	v := New("OUTER")
+	v.SetVector("nested", New("NESTED"))
+	s, ver := v.Snapshot(), v.Version()
+	v.ToVector("nested").SetString("x", "changed")
+	if s.ToVector("nested").ToString("x") != "" || v.ToVector("nested").ToString("x") != "" || v.Version() != ver {
+		t.Error("change to a vector read with ToVector changed the vector holding it")
+	}
+}
+
+type testPoint struct {
+	X, Y int
+}
+
+func TestTypedItem(t *testing.T) {
+	i := NewTypedItem("a.point", testPoint{1, 2})
+	if p := i.Get(); p.X != 1 || p.Y != 2 {
+		t.Errorf("typed item value is not {1 2}, it is %v", p)
+	}
+	i.Set(testPoint{3, 4})
+	c := i.Clone().(TypedItem[testPoint])
+	if p := c.Get(); p.X != 3 {
+		t.Errorf("cloned typed item value is not {3 4}, it is %v", p)
+	}
+
+	b, err := i.MarshalJSON()
+	if err != nil {
+		t.Error(err)
+	}
+	u := NewTypedItem("", testPoint{})
+	if err := u.UnmarshalJSON(b); err != nil {
+		t.Error(err)
+	}
+	if p := u.Get(); p.Y != 4 {
+		t.Errorf("unmarshaled typed item value is not {3 4}, it is %v", p)
+	}
+
+	if _, ok := NewItem("a.string", "string").(StringItem); !ok {
+		t.Error("NewItem did not return StringItem for string value")
+	}
+
+	v := New("TYPED")
+	SetAs(v, "a.int64", int64(64))
+	SetAs(v, "a.point", testPoint{5, 6})
+	if n := v.ToInt64("a.int64"); n != 64 {
+		t.Errorf("expected 64, received %d", n)
+	}
+	if p, err := GetAs[testPoint](v, "a.point"); err != nil || p.X != 5 {
+		t.Errorf("expected {5 6}, received %v, %v", p, err)
+	}
+	if _, err := GetAs[string](v, "a.point"); err == nil {
+		t.Error("expected type mismatch error")
+	}
+}
+
+func TestTimeItem(t *testing.T) {
+	now := time.Now().UTC()
+	i := NewTimeItem("a.time", now)
+	if v := i.ToTime(); !v.Equal(now) {
+		t.Errorf("time item value is not %v, it is %v", now, v)
+	}
+
+	v := New("TIME")
+	v.Set(i)
+	v.SetString("s.time", "2019-04-09T10:00:00Z")
+	if tm := v.ToTime("s.time"); tm.Year() != 2019 {
+		t.Errorf("time not parsed from string item: %v", tm)
+	}
+	if s := v.ToString("a.time"); s != now.Format(time.RFC3339Nano) {
+		t.Errorf("time item not returned as string: %s", s)
+	}
+}
+
+func TestDurationItem(t *testing.T) {
+	i := NewDurationItem("a.duration", 90*time.Second)
+	if d := i.ToDuration(); d != 90*time.Second {
+		t.Errorf("duration item value is not 1m30s, it is %v", d)
+	}
+	b, err := i.MarshalJSON()
+	if err != nil {
+		t.Error(err)
+	}
+	if !strings.Contains(string(b), `"1m30s"`) {
+		t.Errorf("duration item not transmitted as duration string: %s", b)
+	}
+
+	v := New("DURATION")
+	v.SetString("s.duration", "5m")
+	if d := v.ToDuration("s.duration"); d != 5*time.Minute {
+		t.Errorf("duration not parsed from string item: %v", d)
+	}
+}
+
+func TestTimeDurationTransmission(t *testing.T) {
+	now := time.Now().UTC()
+	v := New("TRANSMIT")
+	v.SetTime("a.time", now)
+	v.SetDuration("a.duration", time.Hour)
+
+	j, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := yaml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jv, yv := New(""), New("")
+	if err := json.Unmarshal(j, jv); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(y, yv); err != nil {
+		t.Fatal(err)
+	}
+	for _, rv := range []*Vector{jv, yv} {
+		if _, ok := rv.Get("a.time").(TimeItem); !ok || !rv.ToTime("a.time").Equal(now) {
+			t.Errorf("time item not restored: %v", rv.Get("a.time"))
+		}
+		if _, ok := rv.Get("a.duration").(DurationItem); !ok || rv.ToDuration("a.duration") != time.Hour {
+			t.Errorf("duration item not restored: %v", rv.Get("a.duration"))
+		}
+	}
+}
+
+func TestUntaggedTimeDurationString(t *testing.T) {
+	for _, s := range []string{"10s", "5m", "2019-04-09T10:00:00.000+02:00"} {
+		i, err := fromMtem(&Mtem{Key: "s.value", Value: s})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := i.(StringItem); !ok {
+			t.Errorf("untagged string %s not restored as a string item: %T", s, i)
+		}
+		if r, _ := getString("s.value", i); r != s {
+			t.Errorf("untagged string %s changed to %s", s, r)
+		}
+	}
+}
+
+func TestRegisterItemType(t *testing.T) {
+	u, _ := url.Parse("https://example.com/path?q=1")
+	v := New("REGISTERED")
+	v.Set(
+		NewTypedItem("a.ip", net.ParseIP("10.0.0.1")),
+		newURLItem("a.url", u),
+	)
+
+	j, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := yaml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jv, yv := New(""), New("")
+	if err := json.Unmarshal(j, jv); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(y, yv); err != nil {
+		t.Fatal(err)
+	}
+	for _, rv := range []*Vector{jv, yv} {
+		ip, ok := rv.Get("a.ip").(TypedItem[net.IP])
+		if !ok || !ip.Get().Equal(net.ParseIP("10.0.0.1")) {
+			t.Errorf("ip item not restored: %#v", rv.Get("a.ip"))
+		}
+		ui, ok := rv.Get("a.url").(*urlItem)
+		if !ok || ui.u.String() != u.String() {
+			t.Errorf("url item not restored: %#v", rv.Get("a.url"))
+		}
+	}
+
+	for _, b := range []string{
+		`[{"key":"a.key","value":"x","type":"unknown"}]`,
+		`[{"key":"a.key","value":"::x::","type":"ip"}]`,
+		`[{"key":"a.key","value":[{"key":"b.key","value":1,"type":"unknown"}],"type":"vector"}]`,
+	} {
+		if err := json.Unmarshal([]byte(b), New("")); err == nil {
+			t.Errorf("expected error unmarshaling %s", b)
+		}
+	}
+	if err := yaml.Unmarshal([]byte("- key: a.key\n  value: x\n  type: unknown\n"), New("")); err == nil {
+		t.Error("expected error unmarshaling unknown yaml type")
+	}
+}
+
+func TestBytesItem(t *testing.T) {
+	blob := []byte{0xff, 0x00, 0xfe, 'a'}
+	i := NewBytesItem("a.bytes", blob)
+	if !bytes.Equal(i.ToBytes(), blob) || !bytes.Equal(i.Value(), blob) {
+		t.Errorf("BytesItem holds %v, value %v", i.ToBytes(), i.Value())
+	}
+	i.SetBytes([]byte("text"))
+	if s := string(i.ToBytes()); s != "text" {
+		t.Errorf("SetBytes: %q", s)
+	}
+
+	v := New("BYTES")
+	v.SetBytes("a.bytes", blob)
+	v.SetBytes("a.text", []byte("plain text"))
+	j, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(j), `"value":"/wD+YQ==","type":"bytes"`) {
+		t.Errorf("bytes not transmitted as base64: %s", j)
+	}
+	y, err := yaml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"!!binary /wD+YQ==", "!!binary cGxhaW4gdGV4dA=="} {
+		if !strings.Contains(string(y), want) {
+			t.Errorf("bytes not transmitted as %s: %s", want, y)
+		}
+	}
+	jv, yv := New(""), New("")
+	if err := json.Unmarshal(j, jv); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(y, yv); err != nil {
+		t.Fatal(err)
+	}
+	for _, rv := range []*Vector{jv, yv} {
+		if _, ok := rv.Get("a.bytes").(BytesItem); !ok || !bytes.Equal(rv.ToBytes("a.bytes"), blob) {
+			t.Errorf("bytes item not restored: %#v", rv.Get("a.bytes"))
+		}
+		if s := string(rv.MustBytes("a.text")); s != "plain text" {
+			t.Errorf("text bytes item not restored: %q", s)
+		}
+	}
+}
+
+func TestBytesItemFrom(t *testing.T) {
+	blob := bytes.Repeat([]byte{0xca, 0xfe}, 4096)
+	var opened int
+	v := New("STREAM")
+	v.Set(NewBytesItemFrom("a.blob", func() (io.ReadCloser, error) {
+		opened++
+		return io.NopCloser(bytes.NewReader(blob)), nil
+	}))
+
+	var b bytes.Buffer
+	if err := v.EncodeJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	if opened != 1 {
+		t.Errorf("reader opened %d times", opened)
+	}
+	j, _ := json.Marshal(v)
+	if b.String() != string(j) {
+		t.Errorf("EncodeJSON differs from MarshalJSON:\n%s\n%s", b.String(), j)
+	}
+	rv := New("")
+	if err := json.Unmarshal(b.Bytes(), rv); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(rv.ToBytes("a.blob"), blob) {
+		t.Error("streamed bytes item not restored")
+	}
+
+	failing := NewBytesItemFrom("a.fail", func() (io.ReadCloser, error) {
+		return nil, io.ErrUnexpectedEOF
+	})
+	if _, err := json.Marshal(failing); err == nil {
+		t.Error("expected error marshaling a failing bytes item to json")
+	}
+	if _, err := yaml.Marshal(failing); err == nil {
+		t.Error("expected error marshaling a failing bytes item to yaml")
+	}
+}
+
+func TestNumericItems(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	pi, _, _ := big.ParseFloat("3.14159265358979323846264338327950288", 10, 128, big.ToNearestEven)
+	dec, _ := ParseDecimal("10.25")
+	third := big.NewRat(1, 3)
+	v := New("NUMERIC")
+	v.SetInt8("n.int8", math.MinInt8)
+	v.SetInt16("n.int16", math.MaxInt16)
+	v.SetInt32("n.int32", math.MinInt32)
+	v.SetUint8("n.uint8", math.MaxUint8)
+	v.SetUint16("n.uint16", math.MaxUint16)
+	v.SetUint32("n.uint32", math.MaxUint32)
+	v.SetFloat32("n.float32", 1.5)
+	v.SetBigInt("n.bigint", huge)
+	v.SetBigFloat("n.bigfloat", pi)
+	v.SetDecimal("n.decimal", dec)
+	v.SetDecimal("n.third", third)
+
+	huge.SetInt64(0)
+	if v.ToBigInt("n.bigint").Sign() == 0 {
+		t.Error("BigIntItem shares the provided value")
+	}
+	huge, _ = new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	j, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"value":"123456789012345678901234567890","type":"bigint"`,
+		`"value":"10.25","type":"decimal"`,
+		`"value":"1/3","type":"decimal"`,
+	} {
+		if !strings.Contains(string(j), want) {
+			t.Errorf("expected %s in %s", want, j)
+		}
+	}
+	y, err := yaml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jv, yv := New(""), New("")
+	if err := json.Unmarshal(j, jv); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(y, yv); err != nil {
+		t.Fatal(err)
+	}
+	for _, rv := range []*Vector{jv, yv} {
+		for _, i := range v.List() {
+			if o := rv.Get(i.Key()); reflect.TypeOf(o) != reflect.TypeOf(i) {
+				t.Errorf("%s restored as %T, not %T", i.Key(), o, i)
+			}
+		}
+		if rv.ToInt8("n.int8") != math.MinInt8 || rv.ToUint32("n.uint32") != math.MaxUint32 || rv.ToFloat32("n.float32") != 1.5 {
+			t.Errorf("fixed width items not restored: %v", rv.List())
+		}
+		if rv.ToBigInt("n.bigint").Cmp(huge) != 0 {
+			t.Errorf("bigint restored as %v", rv.ToBigInt("n.bigint"))
+		}
+		if rv.ToBigFloat("n.bigfloat").Cmp(pi) != 0 {
+			t.Errorf("bigfloat restored as %v", rv.ToBigFloat("n.bigfloat").Text('g', -1))
+		}
+		if rv.ToDecimal("n.decimal").Cmp(dec) != 0 || rv.ToDecimal("n.third").Cmp(third) != 0 {
+			t.Errorf("decimals restored as %v, %v", rv.ToDecimal("n.decimal"), rv.ToDecimal("n.third"))
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`[{"key":"n","value":300,"type":"int8"}]`), New("")); err == nil {
+		t.Error("expected overflow error restoring int8")
+	}
+}
+
+func TestListItems(t *testing.T) {
+	v := New("LISTS")
+	v.SetInts("l.ints", 1, -2, 3)
+	v.SetFloat64s("l.float64s", 1, 2.5)
+	v.SetBools("l.bools", true, false)
+	v.SetList("l.list", "a", 1, int64(2), 3.5, true, big.NewInt(4), []string{"b"}, []interface{}{uint8(5)})
+
+	j, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := yaml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jv, yv := New(""), New("")
+	if err := json.Unmarshal(j, jv); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(y, yv); err != nil {
+		t.Fatal(err)
+	}
+	for _, rv := range []*Vector{jv, yv} {
+		for _, i := range v.List() {
+			if o := rv.Get(i.Key()); reflect.TypeOf(o) != reflect.TypeOf(i) || !reflect.DeepEqual(o.Provided(), i.Provided()) {
+				t.Errorf("%s restored as %T %v, not %T %v", i.Key(), o, o.Provided(), i, i.Provided())
+			}
+		}
+	}
+
+	u := New("")
+	err = json.Unmarshal([]byte(`[
+		{"key":"u.ints","value":[1,2,3]},
+		{"key":"u.floats","value":[1,2.5]},
+		{"key":"u.bools","value":[true,false]},
+		{"key":"u.mixed","value":[1,"a",true]}
+	]`), u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := u.ToInts("u.ints"); !reflect.DeepEqual(n, []int{1, 2, 3}) {
+		t.Errorf("untyped number array restored as %v", u.Get("u.ints"))
+	}
+	if f := u.ToFloat64s("u.floats"); !reflect.DeepEqual(f, []float64{1, 2.5}) {
+		t.Errorf("untyped float array restored as %v", u.Get("u.floats"))
+	}
+	if b := u.ToBools("u.bools"); !reflect.DeepEqual(b, []bool{true, false}) {
+		t.Errorf("untyped bool array restored as %v", u.Get("u.bools"))
+	}
+	if l := u.ToList("u.mixed"); !reflect.DeepEqual(l, []interface{}{int64(1), "a", true}) {
+		t.Errorf("untyped mixed array restored as %#v", u.Get("u.mixed"))
 	}
 }
@@ -0,0 +1,191 @@
+package data
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/Laughs-In-Flowers/xrr"
+)
+
+var (
+	DuplicateLayerError = xrr.Xrror("layer %s already exists").Out
+	UnknownLayerError   = xrr.Xrror("no layer %s").Out
+	NilLayerError       = xrr.Xrror("layer %s has no vector or store").Out
+)
+
+// A stack of named Vector or Store layers read as one Vector, each layer taking
+// precedence over those beneath it, e.g. defaults < files < env < flags. The
+// Layered is itself a frozen Vector holding the effective Item of its layers,
+// so that Get, any ToX method, TemplateData, Watch, etc. read through it. It
+// changes only as a layer is pushed or reloaded.
+type Layered struct {
+	*Vector
+	mu     sync.Mutex
+	rl     sync.Mutex
+	layers []*layer
+	src    map[string]string
+}
+
+type layer struct {
+	name string
+	v    *Vector
+	s    Store
+	l    []Item
+}
+
+// Returns a new, empty Layered with the provided tag.
+func NewLayered(tag string, o ...Option) *Layered {
+	v := New(tag, o...)
+	v.Freeze()
+	return &Layered{Vector: v, src: make(map[string]string)}
+}
+
+// Pushes the provided Vector as a new top layer, taking precedence over every
+// layer already held. Changes to the Vector are read on Reload.
+func (l *Layered) Push(name string, v *Vector) error {
+	return l.push(&layer{name: name, v: v})
+}
+
+// Pushes the Vector read from the provided Store as a new top layer, taking
+// precedence over every layer already held. The Store is read again on
+// Reload.
+func (l *Layered) PushStore(name string, s Store) error {
+	return l.push(&layer{name: name, s: s})
+}
+
+func (l *Layered) push(ly *layer) error {
+	if ly.v == nil && ly.s == nil {
+		return NilLayerError(ly.name)
+	}
+	if err := ly.read(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.find(ly.name) != nil {
+		return DuplicateLayerError(ly.name)
+	}
+	l.layers = append(l.layers, ly)
+	l.rebuild()
+	return nil
+}
+
+// Reads the named layer again from its Vector or Store, leaving every other
+// layer as last read. Reloads are made one at a time, a Reload waiting on any
+// in progress.
+func (l *Layered) Reload(name string) error {
+	l.rl.Lock()
+	defer l.rl.Unlock()
+	l.mu.Lock()
+	ly := l.find(name)
+	l.mu.Unlock()
+	if ly == nil {
+		return UnknownLayerError(name)
+	}
+	nl := &layer{name: ly.name, v: ly.v, s: ly.s}
+	if err := nl.read(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for n, o := range l.layers {
+		if o == ly {
+			l.layers[n] = nl
+			l.rebuild()
+			return nil
+		}
+	}
+	return UnknownLayerError(name)
+}
+
+// Returns the names of the layers held, from lowest to highest precedence.
+func (l *Layered) Layers() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var ret []string
+	for _, ly := range l.layers {
+		ret = append(ret, ly.name)
+	}
+	return ret
+}
+
+// Returns the name of the layer supplying the Item at the provided key, or an
+// empty string where no layer holds the key.
+func (l *Layered) Source(k string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.src[k]
+}
+
+func (l *Layered) find(name string) *layer {
+	for _, ly := range l.layers {
+		if ly.name == name {
+			return ly
+		}
+	}
+	return nil
+}
+
+// Reads a copy of the Item held by the layer's Vector or Store.
+func (ly *layer) read() error {
+	v := ly.v
+	if ly.s != nil {
+		var err error
+		if v, err = ly.s.In(); err != nil {
+			return err
+		}
+	}
+	ly.l = nil
+	for _, i := range v.List() {
+		if !identifying(i.Key()) {
+			ly.l = append(ly.l, i.Clone())
+		}
+	}
+	return nil
+}
+
+// Sets the effective Item of the layers, each layer overriding those beneath
+// it. Must be called under lock.
+func (l *Layered) rebuild() {
+	eff := make(map[string]Item)
+	src := make(map[string]string)
+	for _, ly := range l.layers {
+		for _, i := range ly.l {
+			eff[i.Key()], src[i.Key()] = i, ly.name
+		}
+	}
+	l.src = src
+	l.restack(eff)
+}
+
+// Replaces the Item held by this Vector, other than its identifying Item,
+// with the provided Item, changing only Item that differ, regardless of
+// whether the Vector is frozen.
+func (v *Vector) restack(items map[string]Item) {
+	v.l.Lock()
+	defer v.l.Unlock()
+	frozen := v.frozen
+	v.frozen = false
+	defer func() { v.frozen = frozen }()
+
+	var gone, keys []string
+	v.walk(nil, func(p Prefix, _ Item) error {
+		if _, ok := items[string(p)]; !ok && !identifying(string(p)) {
+			gone = append(gone, string(p))
+		}
+		return nil
+	})
+	v.remove(gone...)
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		i := items[k]
+		old := v.get(Prefix(k))
+		if _, fn := i.(FuncItem); fn || old == nil || itemTypeName(old) != itemTypeName(i) || !bytes.Equal(old.Value(), i.Value()) {
+			v.setItems(OpSet, i.Clone())
+		}
+	}
+}
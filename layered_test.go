@@ -0,0 +1,161 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLayered(t *testing.T) {
+	defaults := New("DEFAULTS")
+	defaults.SetString("db.host", "localhost")
+	defaults.SetInt("db.port", 5432)
+	defaults.SetString("mode", "dev")
+
+	trs := []string{"yaml", currentDir, "layered"}
+	file := New("FILE")
+	file.SetStrings("store.retrieval.string", trs...)
+	file.SetString("db.host", "db.internal")
+	s, _ := GetStore("yaml", trs)
+	s.Swap(file)
+	if _, err := s.Out(); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filepath.Join(currentDir, "layered.yaml"))
+
+	flags := New("FLAGS")
+	flags.SetString("mode", "prod")
+
+	l := NewLayered("LAYERED")
+	for _, err := range []error{
+		l.Push("defaults", defaults),
+		l.PushStore("file", s),
+		l.Push("flags", flags),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Push("flags", flags); err == nil {
+		t.Error("expected error pushing duplicate layer")
+	}
+	if err := l.Push("nil", nil); err == nil {
+		t.Error("expected error pushing nil vector")
+	}
+	if err := l.PushStore("nil", nil); err == nil {
+		t.Error("expected error pushing nil store")
+	}
+	if got, want := l.Layers(), []string{"defaults", "file", "flags"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected layers %v, received %v", want, got)
+	}
+
+	for k, want := range map[string]string{
+		"db.host": "db.internal",
+		"mode":    "prod",
+	} {
+		if got := l.ToString(k); got != want {
+			t.Errorf("%s: expected %q, received %q", k, want, got)
+		}
+	}
+	if p := l.ToInt("db.port"); p != 5432 {
+		t.Errorf("expected default port, received %d", p)
+	}
+	for k, want := range map[string]string{
+		"db.host": "file",
+		"db.port": "defaults",
+		"mode":    "flags",
+		"missing": "",
+	} {
+		if got := l.Source(k); got != want {
+			t.Errorf("%s: expected source %q, received %q", k, want, got)
+		}
+	}
+	if l.Tag() != "LAYERED" {
+		t.Errorf("expected layered tag, received %s", l.Tag())
+	}
+	if d := l.TemplateData(); d["Mode"] != "prod" || d["DbPort"] != 5432 {
+		t.Errorf("unexpected template data %v", d)
+	}
+
+	l.SetString("mode", "changed")
+	if err := l.TrySet(NewStringItem("mode", "changed")); err != ErrFrozen {
+		t.Errorf("expected ErrFrozen, received %v", err)
+	}
+
+	c, cancel := l.Watch("")
+	defer cancel()
+	flags.Delete("mode")
+	flags.SetString("extra", "flag")
+	defaults.SetInt("db.port", 6543)
+	if l.ToString("mode") != "prod" {
+		t.Error("layer change visible before reload")
+	}
+	if err := l.Reload("flags"); err != nil {
+		t.Fatal(err)
+	}
+	if l.ToString("mode") != "dev" || l.Source("mode") != "defaults" || l.ToString("extra") != "flag" {
+		t.Errorf("expected flags layer reloaded, received mode %q from %s", l.ToString("mode"), l.Source("mode"))
+	}
+	if p := l.ToInt("db.port"); p != 5432 {
+		t.Errorf("expected unreloaded layer unchanged, received %d", p)
+	}
+	receive(t, c, "extra", OpSet)
+	receive(t, c, "mode", OpSet)
+
+	if err := l.Reload("missing"); err == nil {
+		t.Error("expected error reloading unknown layer")
+	}
+}
+
+type slowStore struct {
+	Store
+	v       *Vector
+	in, max int32
+}
+
+func (s *slowStore) In() (*Vector, error) {
+	if n := atomic.AddInt32(&s.in, 1); n > atomic.LoadInt32(&s.max) {
+		atomic.StoreInt32(&s.max, n)
+	}
+	time.Sleep(10 * time.Millisecond)
+	atomic.AddInt32(&s.in, -1)
+	return s.v, nil
+}
+
+func TestLayeredConcurrentReload(t *testing.T) {
+	a := New("A")
+	a.SetInt("n", 0)
+	s := &slowStore{v: a}
+	l := NewLayered("LAYERED")
+	if err := l.PushStore("a", s); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for n := 1; n <= 5; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- l.Reload("a")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("concurrent reload failed: %v", err)
+		}
+	}
+	if m := atomic.LoadInt32(&s.max); m != 1 {
+		t.Errorf("expected reloads one at a time, received %d at once", m)
+	}
+	a.SetInt("n", 1)
+	if err := l.Reload("a"); err != nil || l.ToInt("n") != 1 {
+		t.Errorf("expected reload to hold 1, received %d, %v", l.ToInt("n"), err)
+	}
+}
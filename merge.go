@@ -0,0 +1,225 @@
+package data
+
+import (
+	"bytes"
+
+	"github.com/Laughs-In-Flowers/xrr"
+)
+
+// A function resolving the merge of Item n onto Item o at key k. o is nil when
+// k is not held. Returning a nil Item leaves the key unchanged, returning an
+// error stops the merge with no changes made.
+type MergeStrategy func(k string, o, n Item) (Item, error)
+
+// A key held with differing values by both sides of a merge, and the Item the
+// MergeStrategy resolved it to.
+type MergeConflict struct {
+	Key      string
+	Old      Item
+	New      Item
+	Resolved Item
+}
+
+// A report of the conflicts met during a merge, and the identifying keys
+// ("vector.tag", "vector.id") skipped by it.
+type MergeReport struct {
+	Conflicts []*MergeConflict
+	Skipped   []string
+}
+
+var (
+	MergeConflictError   = xrr.Xrror("merge conflict at key %s").Out
+	MergeContentionError = xrr.Xrror("merge abandoned after %d attempts, the vector changing throughout").Out
+)
+
+// The number of times a merge is resolved before MergeWith gives up.
+const mergeAttempts = 8
+
+var (
+	// The new Item always replaces the existing Item.
+	MergeOverwrite MergeStrategy = mergeOverwrite
+	// The existing Item is always retained.
+	MergeKeepExisting MergeStrategy = mergeKeepExisting
+	// Any key held with differing values is an error.
+	MergeErrorOnConflict MergeStrategy = mergeErrorOnConflict
+	// VectorItem held on both sides are merged recursively, anything else is
+	// overwritten.
+	MergeDeep MergeStrategy = mergeDeep
+	// StringsItem held on both sides are appended, anything else is
+	// overwritten.
+	MergeAppend MergeStrategy = mergeAppend
+	// StringsItem held on both sides are joined without duplicates, anything
+	// else is overwritten.
+	MergeUnion MergeStrategy = mergeUnion
+)
+
+func mergeOverwrite(k string, o, n Item) (Item, error) {
+	return n, nil
+}
+
+func mergeKeepExisting(k string, o, n Item) (Item, error) {
+	if o != nil {
+		return o, nil
+	}
+	return n, nil
+}
+
+func mergeErrorOnConflict(k string, o, n Item) (Item, error) {
+	if o != nil {
+		return nil, MergeConflictError(k)
+	}
+	return n, nil
+}
+
+func mergeDeep(k string, o, n Item) (Item, error) {
+	ov, nv := copyVector(o), heldVector(n)
+	if ov == nil || nv == nil {
+		return n, nil
+	}
+	r, err := ov.MergeWith(mergeDeep, nv)
+	if err != nil {
+		return nil, err
+	}
+	return &deepMerged{NewVectorItem(k, ov), r.Conflicts}, nil
+}
+
+// A VectorItem resolved by MergeDeep, carrying the conflicts met within it to
+// the MergeReport.
+type deepMerged struct {
+	Item
+	conflicts []*MergeConflict
+}
+
+func mergeStrings(k string, o, n Item, unique bool) (Item, error) {
+	os, ook := o.(StringsItem)
+	ns, nok := n.(StringsItem)
+	if !ook || !nok {
+		return n, nil
+	}
+	l := os.ToStrings()
+	for _, s := range ns.ToStrings() {
+		if !unique || !inList(s, l) {
+			l = append(l, s)
+		}
+	}
+	return NewStringsItem(k, l...), nil
+}
+
+func mergeAppend(k string, o, n Item) (Item, error) {
+	return mergeStrings(k, o, n, false)
+}
+
+func mergeUnion(k string, o, n Item) (Item, error) {
+	return mergeStrings(k, o, n, true)
+}
+
+func identifying(k string) bool {
+	return k == "vector.tag" || k == "vector.id"
+}
+
+// Merges the provided Vector into this Vector, in order, resolving each key
+// with the MergeStrategy. Keys held with equal values are left unchanged, and
+// the identifying keys "vector.tag" and "vector.id" are skipped. The
+// MergeStrategy is called without lock against a Snapshot of this Vector, so
+// that it may read this Vector, and is called again where a key it resolved
+// changes before the merge is made, up to a limit after which the merge fails
+// with MergeContentionError. All changes are made under a single lock, and
+// none are made if the MergeStrategy returns an error.
+func (v *Vector) MergeWith(s MergeStrategy, vs ...*Vector) (*MergeReport, error) {
+	var ls [][]Item
+	for _, vv := range vs {
+		ls = append(ls, vv.List())
+	}
+	r := &MergeReport{}
+	for n := 0; n < mergeAttempts; n++ {
+		if v.Frozen() {
+			return &MergeReport{}, ErrFrozen
+		}
+		m := &merge{
+			r:       &MergeReport{},
+			held:    make(map[string]mark),
+			pending: make(map[string]Item),
+		}
+		if err := m.resolve(v.Snapshot(), s, ls); err != nil {
+			return m.r, err
+		}
+		if ok, err := v.applyMerge(m); ok || err != nil {
+			return m.r, err
+		}
+		r = m.r
+	}
+	return r, MergeContentionError(mergeAttempts)
+}
+
+// The resolutions of a merge, with the Item held at each key resolved when
+// resolved.
+type merge struct {
+	r       *MergeReport
+	held    map[string]mark
+	pending map[string]Item
+	order   []string
+}
+
+func (m *merge) resolve(sn *Snapshot, s MergeStrategy, ls [][]Item) error {
+	for _, l := range ls {
+		for _, n := range l {
+			k := n.Key()
+			if identifying(k) {
+				if !inList(k, m.r.Skipped) {
+					m.r.Skipped = append(m.r.Skipped, k)
+				}
+				continue
+			}
+			o, ok := m.pending[k]
+			if !ok {
+				o = sn.t.get(Prefix(k))
+				m.held[k] = markOf(o)
+			}
+			if o != nil && bytes.Equal(o.Value(), n.Value()) {
+				continue
+			}
+			ri, err := s(k, o, n)
+			var nested []*MergeConflict
+			if d, ok := ri.(*deepMerged); ok {
+				ri, nested = d.Item, d.conflicts
+			}
+			if o != nil {
+				m.r.Conflicts = append(m.r.Conflicts, &MergeConflict{k, o, n, ri})
+			}
+			for _, c := range nested {
+				c.Key = k + "." + c.Key
+				m.r.Conflicts = append(m.r.Conflicts, c)
+			}
+			if err != nil {
+				return err
+			}
+			if ri == nil {
+				continue
+			}
+			if _, ok := m.pending[k]; !ok {
+				m.order = append(m.order, k)
+			}
+			m.pending[k] = ri
+		}
+	}
+	return nil
+}
+
+// Sets the resolved Item of the merge where every key resolved still holds
+// the Item it was resolved against, returning false otherwise.
+func (v *Vector) applyMerge(m *merge) (bool, error) {
+	v.l.Lock()
+	defer v.l.Unlock()
+	if v.frozen {
+		return false, ErrFrozen
+	}
+	for k, o := range m.held {
+		if markOf(v.get(Prefix(k))) != o {
+			return false, nil
+		}
+	}
+	for _, k := range m.order {
+		v.setItems(OpMerge, m.pending[k].Clone())
+	}
+	return true, nil
+}
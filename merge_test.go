@@ -0,0 +1,166 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func mergeVectors() (*Vector, *Vector) {
+	a := New("A")
+	a.SetString("a.string", "a")
+	a.SetStrings("a.list", "x", "y")
+	a.SetInt("a.same", 1)
+	na := New("nested")
+	na.SetString("n.a", "a")
+	na.SetString("n.both", "a")
+	a.SetVector("a.vector", na)
+
+	b := New("B")
+	b.SetString("a.string", "b")
+	b.SetStrings("a.list", "y", "z")
+	b.SetInt("a.same", 1)
+	b.SetBool("b.bool", true)
+	nb := New("nested")
+	nb.SetString("n.b", "b")
+	nb.SetString("n.both", "b")
+	b.SetVector("a.vector", nb)
+	return a, b
+}
+
+func TestMergeWith(t *testing.T) {
+	a, b := mergeVectors()
+	r := a.Merge(b)
+	if len(r.Conflicts) != 3 || len(r.Skipped) != 1 {
+		t.Errorf("unexpected merge report: %d conflicts, skipped %v", len(r.Conflicts), r.Skipped)
+	}
+	if a.ToString("a.string") != "b" || !a.ToBool("b.bool") || a.Tag() != "A" {
+		t.Error("overwrite merge incorrect")
+	}
+
+	a, b = mergeVectors()
+	a.MergeWith(MergeKeepExisting, b)
+	if a.ToString("a.string") != "a" || !a.ToBool("b.bool") {
+		t.Error("keep existing merge incorrect")
+	}
+
+	a, b = mergeVectors()
+	if _, err := a.MergeWith(MergeErrorOnConflict, b); err == nil {
+		t.Error("expected error merging conflicting vectors")
+	}
+	if a.ToString("a.string") != "a" || a.Get("b.bool") != nil {
+		t.Error("failed merge was partially applied")
+	}
+
+	a, b = mergeVectors()
+	a.MergeWith(MergeDeep, b)
+	n := a.ToVector("a.vector")
+	if n.ToString("n.a") != "a" || n.ToString("n.b") != "b" || n.ToString("n.both") != "b" {
+		t.Errorf("deep merge incorrect: %v", n.List())
+	}
+
+	a, b = mergeVectors()
+	a.MergeWith(MergeAppend, b)
+	if l := a.ToStrings("a.list"); len(l) != 4 {
+		t.Errorf("append merge incorrect: %v", l)
+	}
+
+	a, b = mergeVectors()
+	a.MergeWith(MergeUnion, b)
+	if l := a.ToStrings("a.list"); len(l) != 3 || l[2] != "z" {
+		t.Errorf("union merge incorrect: %v", l)
+	}
+
+	a, b = mergeVectors()
+	custom := func(k string, o, n Item) (Item, error) {
+		if k == "a.string" {
+			return NewStringItem(k, "custom"), nil
+		}
+		return nil, nil
+	}
+	a.MergeWith(custom, b)
+	if a.ToString("a.string") != "custom" || a.Get("b.bool") != nil {
+		t.Error("custom merge incorrect")
+	}
+}
+
+func TestMergeWithReading(t *testing.T) {
+	a, b := mergeVectors()
+	reading := func(k string, o, n Item) (Item, error) {
+		if k == "b.bool" {
+			return NewStringItem(k, a.ToString("a.string")), nil
+		}
+		return n, nil
+	}
+	done := make(chan struct{})
+	go func() {
+		a.MergeWith(reading, b)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("merge strategy reading the merged vector deadlocked")
+	}
+	if a.ToString("b.bool") != "a" || a.ToString("a.string") != "b" {
+		t.Errorf("reading merge incorrect: %v", a.List())
+	}
+
+	a, b = mergeVectors()
+	var calls int
+	changing := func(k string, o, n Item) (Item, error) {
+		if k == "a.string" {
+			calls++
+			if calls == 1 {
+				a.SetString("a.string", "changed")
+			}
+			return NewStringItem(k, toString(o)+"+"+toString(n)), nil
+		}
+		return nil, nil
+	}
+	a.MergeWith(changing, b)
+	if s := a.ToString("a.string"); calls != 2 || s != "changed+b" {
+		t.Errorf("merge resolved against a changed key: %s after %d calls", s, calls)
+	}
+}
+
+// An Item of a type that is not comparable.
+type uncomparableItem struct {
+	Item
+	tags []string
+}
+
+func TestMergeWithReport(t *testing.T) {
+	a, b := mergeVectors()
+	r, _ := a.MergeWith(MergeDeep, b)
+	var keys []string
+	for _, c := range r.Conflicts {
+		keys = append(keys, c.Key)
+	}
+	if !inList("a.vector.n.both", keys) || inList("a.vector.n.a", keys) {
+		t.Errorf("expected nested conflicts reported, received %v", keys)
+	}
+
+	a, b = mergeVectors()
+	a.Set(uncomparableItem{NewStringItem("a.held", "a"), nil})
+	b.Set(uncomparableItem{NewStringItem("a.held", "b"), nil})
+	if _, err := a.MergeWith(MergeOverwrite, b); err != nil || a.ToString("a.held") != "b" {
+		t.Errorf("merge of uncomparable items incorrect: %v", err)
+	}
+	tx := a.BeginOptimistic()
+	tx.Get("a.held")
+	tx.SetString("a.string", "tx")
+	if err := tx.Commit(); err != nil {
+		t.Errorf("commit after reading an uncomparable item failed: %v", err)
+	}
+
+	a, b = mergeVectors()
+	var calls int
+	contended := func(k string, o, n Item) (Item, error) {
+		calls++
+		a.SetString(k, "changed")
+		return n, nil
+	}
+	if _, err := a.MergeWith(contended, b); err == nil || calls > 100 {
+		t.Errorf("expected contended merge to fail, received %v after %d calls", err, calls)
+	}
+}
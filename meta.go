@@ -0,0 +1,99 @@
+package data
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Metadata of an Item. Created, Updated and Revision are maintained by the
+// Vector holding the Item; Source and Comment are free to be set by anyone,
+// with Source set by a Store to the location an Item was read from, e.g.
+// "yaml:/etc/app/config.yaml". Expires, where set, is the time after which a
+// Vector treats the Item as absent, see SetWithTTL.
+type Meta struct {
+	Created  time.Time  `json:"created" yaml:"created"`
+	Updated  time.Time  `json:"updated" yaml:"updated"`
+	Revision uint64     `json:"revision" yaml:"revision"`
+	Source   string     `json:"source,omitempty" yaml:"source,omitempty"`
+	Comment  string     `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Expires  *time.Time `json:"expires,omitempty" yaml:"expires,omitempty"`
+}
+
+// Updates the Meta of an Item set over old, which may be nil. An Item replacing
+// another continues its revisions, creation time, and any comment. An Item set
+// fresh starts at revision 1, unless it carries revisions of its own, e.g. as
+// restored by a Store.
+func (v *Vector) stamp(old, i Item) {
+	m, now := i.Meta(), v.now()
+	switch {
+	case old != nil:
+		om := old.Meta()
+		if om.Revision > m.Revision {
+			m.Revision = om.Revision
+		}
+		m.Revision++
+		m.Created = om.Created
+		if m.Comment == "" {
+			m.Comment = om.Comment
+		}
+	case m.Revision == 0:
+		m.Revision, m.Created = 1, now
+	default:
+		return
+	}
+	m.Updated = now
+}
+
+// Sets whether this Vector transmits the Meta of each Item it holds.
+func (v *Vector) TransmitMeta(on bool) {
+	v.l.Lock()
+	v.tm = on
+	v.l.Unlock()
+}
+
+// Returns the Item to transmit, with any FuncItem evaluated, wrapped to
+// transmit their Meta as requested.
+func (v *Vector) transmitted() ([]Item, error) {
+	l, err := v.evaluated(v.List())
+	if err != nil {
+		return nil, err
+	}
+	v.l.RLock()
+	tm := v.tm
+	v.l.RUnlock()
+	if tm {
+		for n, i := range l {
+			l[n] = metaItem{i}
+		}
+	}
+	return l, nil
+}
+
+type metaItem struct {
+	Item
+}
+
+// json.Marshaler for the wrapped Item, adding its Meta.
+func (i metaItem) MarshalJSON() ([]byte, error) {
+	b, err := i.Item.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var m Mtem
+	if err := decodeJSON(b, &m); err != nil {
+		return nil, err
+	}
+	m.Meta = i.Meta()
+	return json.Marshal(&m)
+}
+
+// yaml.Marshaler for the wrapped Item, adding its Meta.
+func (i metaItem) MarshalYAML() (interface{}, error) {
+	y, err := i.Item.MarshalYAML()
+	if m, ok := y.(*Mtem); ok {
+		c := *m
+		c.Meta = i.Meta()
+		return &c, err
+	}
+	return y, err
+}
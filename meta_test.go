@@ -0,0 +1,122 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+func TestMeta(t *testing.T) {
+	v := New("META")
+	v.SetString("a.key", "one")
+	m := *v.Get("a.key").Meta()
+	if m.Revision != 1 || m.Created.IsZero() || !m.Updated.Equal(m.Created) {
+		t.Errorf("unexpected meta on first set: %+v", m)
+	}
+	v.Get("a.key").Meta().Comment = "the key"
+
+	v.SetString("a.key", "two")
+	n := *v.Get("a.key").Meta()
+	if n.Revision != 2 || !n.Created.Equal(m.Created) || n.Updated.Before(m.Updated) || n.Comment != "the key" {
+		t.Errorf("unexpected meta on second set: %+v", n)
+	}
+	if c := v.Get("a.key").Clone().Meta(); *c != n {
+		t.Errorf("clone meta %+v, not %+v", c, n)
+	}
+
+	j, _ := json.Marshal(v)
+	if strings.Contains(string(j), `"meta"`) {
+		t.Errorf("meta transmitted without request: %s", j)
+	}
+	v.TransmitMeta(true)
+	j, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := yaml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jv, yv := New(""), New("")
+	if err := json.Unmarshal(j, jv); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(y, yv); err != nil {
+		t.Fatal(err)
+	}
+	for _, rv := range []*Vector{jv, yv} {
+		r := rv.Get("a.key").Meta()
+		if r.Revision != 2 || !r.Created.Equal(n.Created) || r.Comment != "the key" {
+			t.Errorf("meta not restored: %+v", r)
+		}
+	}
+	if strings.Contains(string(j), `"expires"`) || strings.Contains(string(y), "expires") {
+		t.Errorf("unset expiry transmitted:\n%s\n%s", j, y)
+	}
+
+	v.SetWithTTL(NewStringItem("a.ttl", "short"), time.Hour)
+	if j, err = json.Marshal(v); err != nil {
+		t.Fatal(err)
+	}
+	if y, err = yaml.Marshal(v); err != nil {
+		t.Fatal(err)
+	}
+	jv, yv = New(""), New("")
+	json.Unmarshal(j, jv)
+	yaml.Unmarshal(y, yv)
+	e := v.Get("a.ttl").Meta().Expires
+	for _, rv := range []*Vector{jv, yv} {
+		if r := rv.Get("a.ttl").Meta().Expires; r == nil || !r.Equal(*e) {
+			t.Errorf("expiry not restored: %v, not %v", r, e)
+		}
+		if r := rv.Get("a.key").Meta().Expires; r != nil {
+			t.Errorf("unset expiry restored as %v", r)
+		}
+	}
+}
+
+func TestMetaSource(t *testing.T) {
+	a, v := New("A"), New("V")
+	a.SetString("k", "a")
+	v.SetString("k", "v")
+	v.SetString("k", "v2")
+	v.Merge(a)
+	if r := a.Get("k").Meta().Revision; r != 1 {
+		t.Errorf("merge changed the revision of the merged item to %d", r)
+	}
+	if r := v.Get("k").Meta().Revision; r != 3 {
+		t.Errorf("expected merged revision 3, received %d", r)
+	}
+
+	i := NewStringItem("k", "ttl")
+	v.SetWithTTL(i, time.Hour)
+	if i.Meta().Expires != nil || v.Get("k").Meta().Expires == nil {
+		t.Errorf("expected expiry set on the held item only, received %v", i.Meta().Expires)
+	}
+}
+
+func TestStoreMeta(t *testing.T) {
+	trs := []string{"yaml", currentDir, "meta"}
+	in := New("META")
+	in.SetStrings("store.retrieval.string", trs...)
+	in.SetString("a.key", "value")
+	s, _ := GetStore("yaml", trs)
+	s.Swap(in)
+	if _, err := s.Out(); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filepath.Join(currentDir, "meta.yaml"))
+	out, err := s.In()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "yaml:" + filepath.Join(currentDir, "meta.yaml")
+	if src := out.Get("a.key").Meta().Source; src != want {
+		t.Errorf("expected source %s, received %s", want, src)
+	}
+}
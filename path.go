@@ -0,0 +1,213 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Laughs-In-Flowers/xrr"
+)
+
+var PathError = xrr.Xrror("path %s cannot descend through %s, holding %s").Out
+
+type getter interface {
+	Get(string) Item
+}
+
+// Returns the Item at the provided dotted path, descending into any VectorItem
+// or MapItem held along the path, or nil where nothing is held. The longest
+// key held at each level is preferred, e.g. "server.tls.cert" may be found as
+// "server.tls.cert", as "tls.cert" within a VectorItem held at "server", or as
+// "cert" within a MapItem held at "server.tls". An Item found below the top
+// level is returned as a copy keyed by the full path.
+func (v *Vector) GetPath(p string) Item {
+	return getPath(v, p, strings.Split(p, "."))
+}
+
+func getPath(g getter, p string, parts []string) Item {
+	for n := len(parts); n > 0; n-- {
+		i := g.Get(strings.Join(parts[:n], "."))
+		if i == nil {
+			continue
+		}
+		if n == len(parts) {
+			return i
+		}
+		switch ii := i.(type) {
+		case VectorItem:
+			if r := getPath(ii.Get(), p, parts[n:]); r != nil {
+				r = r.Clone()
+				r.NewKey(p)
+				return r
+			}
+		case MapItem:
+			if r, ok := mapPath(ii.ToMap(), parts[n:]); ok {
+				return valueItem(p, r)
+			}
+		}
+	}
+	return nil
+}
+
+func mapPath(m map[string]interface{}, parts []string) (interface{}, bool) {
+	for n := len(parts); n > 0; n-- {
+		e, ok := m[strings.Join(parts[:n], ".")]
+		if !ok {
+			continue
+		}
+		if n == len(parts) {
+			return e, true
+		}
+		if em, ok := e.(map[string]interface{}); ok {
+			if r, ok := mapPath(em, parts[n:]); ok {
+				return r, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Sets the provided value at the dotted path, descending into the VectorItem
+// or MapItem held at the longest key along the path. Within a MapItem any
+// missing level is created as a nested map; where no VectorItem or MapItem is
+// held along the path, the value is set at the full path, as any dotted key.
+// An Item value is set as a copy under its new key, any other value as the
+// Item best suited to its type. Fails without change if a MapItem level holds
+// a value other than a map, or if the path changes while being set.
+func (v *Vector) SetPath(p string, vi interface{}) error {
+	tx := v.BeginOptimistic()
+	if err := setPath(tx, p, strings.Split(p, "."), vi); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func setPath(a applier, p string, parts []string, vi interface{}) error {
+	k := strings.Join(parts, ".")
+	if a.Get(k) == nil {
+		for n := len(parts) - 1; n > 0; n-- {
+			pk := strings.Join(parts[:n], ".")
+			switch i := a.Get(pk).(type) {
+			case VectorItem:
+				nv := copyVector(i)
+				if err := setPath(nv, p, parts[n:], vi); err != nil {
+					return err
+				}
+				a.Set(NewVectorItem(pk, nv))
+				return nil
+			case MapItem:
+				m, err := setMapPath(i.ToMap(), p, parts[n:], vi)
+				if err != nil {
+					return err
+				}
+				a.Set(NewMapItem(pk, m))
+				return nil
+			}
+		}
+	}
+	a.Set(valueItem(k, vi))
+	return nil
+}
+
+// Returns a copy of the provided map with the value set at the path, copying
+// or creating each nested map along the way.
+func setMapPath(m map[string]interface{}, p string, parts []string, vi interface{}) (map[string]interface{}, error) {
+	ret := make(map[string]interface{}, len(m)+1)
+	for k, e := range m {
+		ret[k] = e
+	}
+	k := parts[0]
+	if len(parts) == 1 {
+		if i, ok := vi.(Item); ok {
+			vi = i.Provided()
+		}
+		ret[k] = vi
+		return ret, nil
+	}
+	var nm map[string]interface{}
+	switch e := ret[k].(type) {
+	case nil:
+	case map[string]interface{}:
+		nm = e
+	default:
+		return nil, PathError(p, k, fmt.Sprintf("%T", e))
+	}
+	nm, err := setMapPath(nm, p, parts[1:], vi)
+	if err != nil {
+		return nil, err
+	}
+	ret[k] = nm
+	return ret, nil
+}
+
+// Returns an Item with the provided key for any value.
+func valueItem(k string, vi interface{}) Item {
+	switch i := vi.(type) {
+	case Item:
+		ni := i.Clone()
+		ni.NewKey(k)
+		return ni
+	case string:
+		return NewStringItem(k, i)
+	case []string:
+		return NewStringsItem(k, i...)
+	case time.Duration:
+		return NewDurationItem(k, i)
+	case *Vector:
+		return NewVectorItem(k, i)
+	case []interface{}:
+		return NewListItem(k, i...)
+	}
+	return guessMtem(&Mtem{Key: k, Value: vi})
+}
+
+// Returns the element of a list Item for an indexed key, e.g. "hosts[2]", as an
+// Item keyed by the indexed key, or nil.
+func indexed(k string, get func(string) Item) Item {
+	o := strings.LastIndex(k, "[")
+	if o < 0 || !strings.HasSuffix(k, "]") {
+		return nil
+	}
+	n, err := strconv.Atoi(k[o+1 : len(k)-1])
+	if err != nil || n < 0 {
+		return nil
+	}
+	i := get(k[:o])
+	if i == nil {
+		return nil
+	}
+	if e, ok := element(i, n); ok {
+		return valueItem(k, e)
+	}
+	return nil
+}
+
+func element(i Item, n int) (interface{}, bool) {
+	var e []interface{}
+	switch ii := i.(type) {
+	case StringsItem:
+		if l := ii.ToStrings(); n < len(l) {
+			return l[n], true
+		}
+	case IntsItem:
+		if l := ii.ToInts(); n < len(l) {
+			return l[n], true
+		}
+	case Float64sItem:
+		if l := ii.ToFloat64s(); n < len(l) {
+			return l[n], true
+		}
+	case BoolsItem:
+		if l := ii.ToBools(); n < len(l) {
+			return l[n], true
+		}
+	case ListItem:
+		e = ii.ToList()
+	}
+	if n < len(e) {
+		return e[n], true
+	}
+	return nil, false
+}
@@ -0,0 +1,84 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	tls := New("tls")
+	tls.SetString("tls.cert", "cert.pem")
+	v := New("PATH")
+	v.SetVector("server", tls)
+	v.SetMap("db", map[string]interface{}{
+		"primary": map[string]interface{}{"host": "localhost", "port": 5432},
+	})
+	v.SetString("flat.key", "flat")
+
+	for p, want := range map[string]string{
+		"server.tls.cert": "cert.pem",
+		"db.primary.host": "localhost",
+		"flat.key":        "flat",
+	} {
+		i := v.GetPath(p)
+		if i == nil || toString(i) != want || i.Key() != p {
+			t.Errorf("GetPath(%s) = %v, want %s", p, i, want)
+		}
+	}
+	if i := v.GetPath("db.primary.port"); toInt(i) != 5432 {
+		t.Errorf("GetPath(db.primary.port) = %v", i)
+	}
+	for _, p := range []string{"server.tls.key", "db.replica.host", "nope", "flat.key.more"} {
+		if i := v.GetPath(p); i != nil {
+			t.Errorf("GetPath(%s) = %v, expected nil", p, i)
+		}
+	}
+
+	if err := v.SetPath("server.tls.key", "key.pem"); err != nil {
+		t.Fatal(err)
+	}
+	if s := v.ToVector("server").ToString("tls.key"); s != "key.pem" {
+		t.Errorf("nested vector not set: %q", s)
+	}
+	if tls.Get("tls.key") != nil {
+		t.Error("SetPath changed the vector provided to SetVector")
+	}
+
+	db := v.ToMap("db")
+	if err := v.SetPath("db.replica.host", "remote"); err != nil {
+		t.Fatal(err)
+	}
+	if s := toString(v.GetPath("db.replica.host")); s != "remote" {
+		t.Errorf("intermediate map level not created: %v", v.ToMap("db"))
+	}
+	if _, ok := db["replica"]; ok {
+		t.Error("SetPath changed a previously retrieved map")
+	}
+	if err := v.SetPath("db.primary.host.name", "x"); err == nil {
+		t.Error("expected error setting through a string map value")
+	}
+
+	if err := v.SetPath("new.deep.key", NewBoolItem("ignored", true)); err != nil {
+		t.Fatal(err)
+	}
+	if !v.ToBool("new.deep.key") {
+		t.Errorf("new path not set as dotted key: %v", v.Get("new.deep.key"))
+	}
+}
+
+func TestMapTransmission(t *testing.T) {
+	in := []byte(`[{"key":"a.map","value":{"one":1,"nested":{"two":"2"}}}]`)
+	v := New("")
+	if err := json.Unmarshal(in, v); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := v.Get("a.map").(MapItem); !ok {
+		t.Fatalf("object restored as %T, not MapItem", v.Get("a.map"))
+	}
+	if n := v.MustMap("a.map")["one"]; n != int64(1) {
+		t.Errorf("map number restored as %T %v", n, n)
+	}
+	if s := toString(v.GetPath("a.map.nested.two")); s != "2" {
+		t.Errorf("nested map value %q", s)
+	}
+}
@@ -0,0 +1,91 @@
+package data
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Returns every Item with a key beginning with the provided prefix, as read by
+// Get, sorted by key. Only the subtree of the prefix is visited. Any FuncItem or
+// RefItem failing to evaluate or resolve is omitted.
+func (v *Vector) Prefix(p string) []Item {
+	return v.query(p, func(string) bool { return true })
+}
+
+// Returns every Item with a key matching the provided pattern, as read by
+// Prefix, sorted by key. A pattern is matched segment by segment, segments being separated by ".",
+// where a "**" segment matches any number of segments, including none, and any
+// other segment is matched as by path.Match, e.g. "*" matches any one segment.
+// A malformed pattern matches nothing.
+//
+//	server.*.port    matches server.http.port, server.grpc.port
+//	server.**.port   matches server.port, server.http.port, server.a.b.port
+//	server.**        matches server itself and any key beneath it
+//	**.port          matches any key ending in a port segment
+func (v *Vector) Glob(pattern string) []Item {
+	ps := strings.Split(pattern, ".")
+	var lit []string
+	for _, s := range ps {
+		if strings.ContainsAny(s, `*?[\`) {
+			break
+		}
+		lit = append(lit, s)
+	}
+	p := strings.Join(lit, ".")
+	if len(lit) < len(ps) && len(lit) > 0 && ps[len(lit)] != "**" {
+		p = p + "."
+	}
+	return v.query(p, func(k string) bool {
+		return glob(ps, strings.Split(k, "."))
+	})
+}
+
+func glob(ps, ks []string) bool {
+	for len(ps) > 0 {
+		if ps[0] == "**" {
+			for n := 0; n <= len(ks); n++ {
+				if glob(ps[1:], ks[n:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(ks) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(ps[0], ks[0]); !ok {
+			return false
+		}
+		ps, ks = ps[1:], ks[1:]
+	}
+	return len(ks) == 0
+}
+
+// Returns every Item with a key matching the provided regular expression, as
+// read by Prefix, sorted by key.
+func (v *Vector) Regexp(re *regexp.Regexp) []Item {
+	return v.query("", re.MatchString)
+}
+
+func (v *Vector) query(p string, fn func(string) bool) []Item {
+	v.l.RLock()
+	var ret []Item
+	w := func(_ Prefix, i Item) error {
+		if fn(i.Key()) && !v.expired(i) {
+			ret = append(ret, v.out(i))
+		}
+		return nil
+	}
+	if p == "" {
+		v.walk(nil, w)
+	} else {
+		v.trie.VisitSubtree(Prefix(p), w)
+	}
+	v.l.RUnlock()
+	sort.Slice(ret, func(a, b int) bool {
+		return ret[a].Key() < ret[b].Key()
+	})
+	return v.read(ret)
+}
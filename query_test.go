@@ -0,0 +1,70 @@
+package data
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func queryVector() *Vector {
+	v := New("QUERY")
+	v.SetInt("server.port", 80)
+	v.SetInt("server.http.port", 8080)
+	v.SetInt("server.grpc.port", 9090)
+	v.SetInt("server.admin.tls.port", 8443)
+	v.SetString("server.http.host", "localhost")
+	v.SetString("serverless", "no")
+	v.SetString("db", "primary")
+	v.SetString("db.host", "db")
+	v.SetInt("db.port", 5432)
+	return v
+}
+
+func TestQuery(t *testing.T) {
+	v := queryVector()
+	for _, tc := range []struct {
+		name string
+		got  []Item
+		want []string
+	}{
+		{"prefix", v.Prefix("server."), []string{"server.admin.tls.port", "server.grpc.port", "server.http.host", "server.http.port", "server.port"}},
+		{"prefix partial", v.Prefix("server"), []string{"server.admin.tls.port", "server.grpc.port", "server.http.host", "server.http.port", "server.port", "serverless"}},
+		{"prefix none", v.Prefix("cache."), nil},
+		{"glob one", v.Glob("server.*.port"), []string{"server.grpc.port", "server.http.port"}},
+		{"glob many", v.Glob("server.**.port"), []string{"server.admin.tls.port", "server.grpc.port", "server.http.port", "server.port"}},
+		{"glob leading", v.Glob("**.port"), []string{"db.port", "server.admin.tls.port", "server.grpc.port", "server.http.port", "server.port"}},
+		{"glob segment", v.Glob("*.h*.*"), []string{"server.http.host", "server.http.port"}},
+		{"glob literal", v.Glob("db.host"), []string{"db.host"}},
+		{"glob parent", v.Glob("db.**"), []string{"db", "db.host", "db.port"}},
+		{"glob subtree", v.Glob("server.**"), []string{"server.admin.tls.port", "server.grpc.port", "server.http.host", "server.http.port", "server.port"}},
+		{"glob malformed", v.Glob("server.[.port"), nil},
+		{"regexp", v.Regexp(regexp.MustCompile(`^(db|server)\.port$`)), []string{"db.port", "server.port"}},
+	} {
+		if got := keyList(tc.got); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected %v, received %v", tc.name, tc.want, got)
+		}
+	}
+
+	r := v.ReadOnly()
+	r.Glob("db.*")[0].Provide("changed")
+	if r.ToString("db.host") != "db" {
+		t.Error("item changed through frozen view query")
+	}
+
+	v.Set(NewFuncItem("db.dsn", func(v *Vector) (interface{}, error) {
+		return v.ToString("db.host") + ":" + v.ToString("db.port"), nil
+	}))
+	v.Set(NewRefItem("db.replica", "db.host"))
+	v.Set(NewFuncItem("db.failing", func(*Vector) (interface{}, error) {
+		return nil, ErrFrozen
+	}))
+	for _, l := range [][]Item{v.Prefix("db."), v.Glob("db.*"), v.Regexp(regexp.MustCompile(`^db\.`))} {
+		got := make(map[string]interface{})
+		for _, i := range l {
+			got[i.Key()] = i.Provided()
+		}
+		if _, failing := got["db.failing"]; failing || got["db.dsn"] != v.ToString("db.dsn") || got["db.replica"] != "db" {
+			t.Errorf("expected items as read by Get, received %v", got)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Laughs-In-Flowers/xrr"
+)
+
+var (
+	UnresolvedError     = xrr.Xrror("unresolved reference %s at %s").Out
+	ReferenceCycleError = xrr.Xrror("reference cycle %s").Out
+)
+
+var reference = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// Resolves every reference held by this Vector in place: each ${other.key} or
+// ${env:NAME} within a StringItem is replaced by the text of the Item at
+// other.key, or of the environment variable NAME. Each RefItem is checked to
+// resolve, and left held to be followed as read. References are followed
+// through any nested VectorItem, where a key is looked up within the nested
+// Vector before each enclosing Vector in turn. Fails without change on a
+// reference cycle or an unresolved reference.
+func (v *Vector) Resolve() error {
+	tx := v.BeginOptimistic()
+	r := newResolver(getterFunc(tx.raw))
+	for _, k := range v.Keys() {
+		i := tx.raw(k)
+		if i == nil {
+			continue
+		}
+		ri, err := r.resolve(k, i)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if changed(i, ri) {
+			tx.Set(ri)
+		}
+	}
+	return tx.Commit()
+}
+
+// Returns the string held at the provided key with any reference resolved as
+// by Resolve, leaving this Vector unchanged.
+func (v *Vector) ResolveString(k string) (string, error) {
+	i := v.raw(k)
+	if i == nil {
+		return "", nil
+	}
+	ri, err := newResolver(getterFunc(v.raw)).resolve(k, i)
+	if err != nil {
+		return "", err
+	}
+	return toString(ri), nil
+}
+
+// Returns a copy of the Item at ref as read by Get, keyed k, following any
+// RefItem held there in turn; refs are the keys of the RefItem followed to k.
+func (v *Vector) deref(k, ref string, refs []string) (Item, error) {
+	refs = append(refs, k)
+	for n, f := range refs {
+		if f == ref {
+			return nil, ReferenceCycleError(strings.Join(append(refs[n:], ref), " -> "))
+		}
+	}
+	t, err := v.eval(ref, refs)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		t = v.GetPath(ref)
+	}
+	if t == nil {
+		return nil, UnresolvedError(ref, k)
+	}
+	c := t.Clone()
+	c.NewKey(k)
+	return c, nil
+}
+
+// Returns the Item held at the provided key, with any FuncItem evaluated but
+// any RefItem as held.
+func (v *Vector) raw(k string) Item {
+	return v.unfollowed(v.held(k)[0])
+}
+
+func (v *Vector) unfollowed(i Item) Item {
+	if i == nil || v.expired(i) {
+		return nil
+	}
+	if f, ok := i.(FuncItem); ok {
+		ri, _ := f.Eval(v)
+		return ri
+	}
+	return i
+}
+
+type getterFunc func(string) Item
+
+func (fn getterFunc) Get(k string) Item {
+	return fn(k)
+}
+
+// Returns true where the resolved Item differs from the Item it resolved, a
+// RefItem never being changed.
+func changed(i, ri Item) bool {
+	_, ref := i.(RefItem)
+	return !ref && ri != i
+}
+
+type scope struct {
+	g getter
+	p string
+}
+
+type resolver struct {
+	scopes []scope
+	active []string
+	done   map[string]Item
+}
+
+func newResolver(g getter) *resolver {
+	return &resolver{
+		scopes: []scope{{g, ""}},
+		done:   make(map[string]Item),
+	}
+}
+
+// Returns the provided Item, held at the full key k, with any reference
+// resolved, or the Item itself where it holds none.
+func (r *resolver) resolve(k string, i Item) (Item, error) {
+	if ri, ok := r.done[k]; ok {
+		return ri, nil
+	}
+	for n, a := range r.active {
+		if a == k {
+			return nil, ReferenceCycleError(strings.Join(append(r.active[n:], k), " -> "))
+		}
+	}
+	r.active = append(r.active, k)
+	ri, err := r.item(k, i)
+	r.active = r.active[:len(r.active)-1]
+	if err != nil {
+		return nil, err
+	}
+	r.done[k] = ri
+	return ri, nil
+}
+
+func (r *resolver) item(k string, i Item) (Item, error) {
+	switch ii := i.(type) {
+	case RefItem:
+		t, err := r.lookup(k, ii.Ref())
+		if err != nil {
+			return nil, err
+		}
+		ni := t.Clone()
+		ni.NewKey(i.Key())
+		return ni, nil
+	case StringItem:
+		s, err := r.interpolate(k, ii.ToString())
+		if err != nil || s == ii.ToString() {
+			return i, err
+		}
+		ni := ii.Clone()
+		ni.Provide(s)
+		return ni, nil
+	case VectorItem:
+		nv := copyVector(ii)
+		r.scopes = append(r.scopes, scope{getterFunc(nv.raw), k + "."})
+		var set []Item
+		for _, nk := range nv.Keys() {
+			ni := nv.raw(nk)
+			if ni == nil {
+				continue
+			}
+			ri, err := r.resolve(k+"."+nk, ni)
+			if err != nil {
+				return nil, err
+			}
+			if changed(ni, ri) {
+				set = append(set, ri)
+			}
+		}
+		r.scopes = r.scopes[:len(r.scopes)-1]
+		if len(set) == 0 {
+			return i, nil
+		}
+		nv.Set(set...)
+		ni := ii.Clone().(VectorItem)
+		ni.SetVector(nv)
+		return ni, nil
+	}
+	return i, nil
+}
+
+// Returns the resolved Item referenced from the full key k, looked up from the
+// innermost scope outward.
+func (r *resolver) lookup(k, ref string) (Item, error) {
+	parts := strings.Split(ref, ".")
+	for n := len(r.scopes) - 1; n >= 0; n-- {
+		t, sk, ss := find(append([]scope(nil), r.scopes[:n+1]...), parts)
+		if t == nil {
+			continue
+		}
+		outer := r.scopes
+		r.scopes = ss
+		ri, err := r.resolve(ss[len(ss)-1].p+sk, t)
+		r.scopes = outer
+		return ri, err
+	}
+	return nil, UnresolvedError(ref, k)
+}
+
+// Returns the Item at the path within the innermost of the provided scopes as
+// GetPath, with its key within the scope it is found in, and the scopes
+// leading to it.
+func find(ss []scope, parts []string) (Item, string, []scope) {
+	s := ss[len(ss)-1]
+	for n := len(parts); n > 0; n-- {
+		k := strings.Join(parts[:n], ".")
+		i := s.g.Get(k)
+		if i == nil {
+			continue
+		}
+		if n == len(parts) {
+			return i, k, ss
+		}
+		switch ii := i.(type) {
+		case VectorItem:
+			nv := ii.Get()
+			ns := append(ss[:len(ss):len(ss)], scope{getterFunc(nv.raw), s.p + k + "."})
+			if r, rk, rs := find(ns, parts[n:]); r != nil {
+				return r, rk, rs
+			}
+		case MapItem:
+			if r, ok := mapPath(ii.ToMap(), parts[n:]); ok {
+				p := strings.Join(parts, ".")
+				return valueItem(p, r), p, ss
+			}
+		}
+	}
+	return nil, "", nil
+}
+
+func (r *resolver) interpolate(k, s string) (string, error) {
+	var err error
+	ret := reference.ReplaceAllStringFunc(s, func(m string) string {
+		if err != nil {
+			return m
+		}
+		ref := m[2 : len(m)-1]
+		if name := strings.TrimPrefix(ref, "env:"); name != ref {
+			e, ok := os.LookupEnv(name)
+			if !ok {
+				err = UnresolvedError(ref, k)
+			}
+			return e
+		}
+		var i Item
+		if i, err = r.lookup(k, ref); err != nil {
+			return m
+		}
+		if t, terr := getString(i.Key(), i); terr == nil {
+			return t
+		}
+		return fmt.Sprint(i.Provided())
+	})
+	return ret, err
+}
@@ -0,0 +1,135 @@
+package data
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	t.Setenv("DATA_TEST_USER", "admin")
+	v := New("RESOLVE")
+	v.SetString("base.url", "https://example.com")
+	v.SetString("api.users", "${base.url}/users")
+	v.SetString("api.login", "${api.users}/login?as=${env:DATA_TEST_USER}")
+	v.SetInt("server.port", 8080)
+	v.SetString("server.addr", "localhost:${server.port}")
+	v.Set(NewRefItem("alias.port", "server.port"))
+	n := New("NESTED")
+	n.SetString("path", "/v1")
+	n.SetString("url", "${base.url}${path}")
+	v.SetVector("nested", n)
+	v.SetString("deep", "${nested.url}")
+
+	if s, err := v.ResolveString("api.login"); err != nil || s != "https://example.com/users/login?as=admin" {
+		t.Errorf("expected resolved string, received %q, %v", s, err)
+	}
+	if s := v.ToString("api.users"); s != "${base.url}/users" {
+		t.Errorf("ResolveString changed vector, received %q", s)
+	}
+
+	if err := v.Resolve(); err != nil {
+		t.Fatal(err)
+	}
+	for k, want := range map[string]string{
+		"api.users":   "https://example.com/users",
+		"api.login":   "https://example.com/users/login?as=admin",
+		"server.addr": "localhost:8080",
+		"deep":        "https://example.com/v1",
+	} {
+		if s := v.ToString(k); s != want {
+			t.Errorf("%s: expected %q, received %q", k, want, s)
+		}
+	}
+	if p := v.ToInt("alias.port"); p != 8080 {
+		t.Errorf("expected alias to resolve to 8080, received %d", p)
+	}
+	if s := v.ToVector("nested").ToString("url"); s != "https://example.com/v1" {
+		t.Errorf("expected nested url resolved, received %q", s)
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		set  []Item
+		want string
+	}{
+		{"cycle", []Item{NewStringItem("a", "${b}"), NewStringItem("b", "${c}"), NewStringItem("c", "${a}")}, "a -> b -> c -> a"},
+		{"self", []Item{NewRefItem("a", "a")}, "a -> a"},
+		{"missing", []Item{NewStringItem("a", "${nothing.here}")}, "unresolved reference nothing.here at a"},
+		{"missing ref", []Item{NewRefItem("a", "nothing.here")}, "unresolved reference nothing.here at a"},
+		{"env", []Item{NewStringItem("a", "${env:DATA_TEST_UNSET}")}, "unresolved reference env:DATA_TEST_UNSET at a"},
+	} {
+		v := New("ERRORS")
+		v.Set(tc.set...)
+		err := v.Resolve()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, received %v", tc.name, tc.want, err)
+		}
+		if v.ToString("a") != toString(tc.set[0]) {
+			t.Errorf("%s: vector changed on failed resolve", tc.name)
+		}
+	}
+}
+
+func TestRefItemTransmission(t *testing.T) {
+	v := New("REF")
+	v.Set(NewRefItem("alias", "target"))
+	b, err := v.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := New("")
+	if err := n.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := n.raw("alias").(RefItem); !ok || r.Ref() != "target" {
+		t.Errorf("expected RefItem to target, received %#v", n.raw("alias"))
+	}
+}
+
+func TestRefItemRead(t *testing.T) {
+	v := New("REF")
+	v.SetInt("server.port", 8080)
+	v.Set(NewRefItem("alias.port", "server.port"), NewRefItem("alias.alias", "alias.port"))
+	n := New("NESTED")
+	n.SetString("url", "https://example.com")
+	v.SetVector("nested", n)
+	v.Set(NewRefItem("alias.url", "nested.url"))
+
+	if p := v.ToInt("alias.port"); p != 8080 {
+		t.Errorf("expected alias to read 8080 before resolve, received %d", p)
+	}
+	if i := v.Get("alias.alias"); i == nil || i.Key() != "alias.alias" || toInt(i) != 8080 {
+		t.Errorf("expected alias of alias to read 8080, received %v", i)
+	}
+	if s := v.ToString("alias.url"); s != "https://example.com" {
+		t.Errorf("expected alias into nested vector, received %q", s)
+	}
+	if err := v.Resolve(); err != nil {
+		t.Fatal(err)
+	}
+	v.SetInt("server.port", 9090)
+	if p := v.ToInt("alias.port"); p != 9090 {
+		t.Errorf("expected alias to follow target after resolve, received %d", p)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"key":"alias.port","value":"server.port","type":"ref"`) {
+		t.Errorf("alias not transmitted as a reference: %s", b)
+	}
+
+	v.Set(NewRefItem("a", "b"), NewRefItem("b", "a"), NewRefItem("c", "nothing"))
+	if _, err := v.Eval("a"); err == nil || !strings.Contains(err.Error(), "a -> b -> a") {
+		t.Errorf("expected reference cycle reading a, received %v", err)
+	}
+	if _, err := v.Eval("c"); err == nil || !strings.Contains(err.Error(), "unresolved reference nothing at c") {
+		t.Errorf("expected unresolved reference reading c, received %v", err)
+	}
+	if v.Get("a") != nil || v.Get("c") != nil {
+		t.Error("expected unresolvable alias to read as nil")
+	}
+}
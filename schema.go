@@ -0,0 +1,430 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Laughs-In-Flowers/xrr"
+)
+
+// Types a Field may declare, named as in JSON Schema, with "time" and
+// "duration" for a TimeItem and DurationItem, each of which is also a
+// "string". A "time" transmits in JSON Schema as a string of format
+// "date-time", and a "duration", being in Go syntax, e.g. "1m30s", rather than
+// ISO 8601, as a string of the custom format "go-duration".
+const (
+	SchemaString   = "string"
+	SchemaInteger  = "integer"
+	SchemaNumber   = "number"
+	SchemaBoolean  = "boolean"
+	SchemaArray    = "array"
+	SchemaObject   = "object"
+	SchemaTime     = "time"
+	SchemaDuration = "duration"
+)
+
+// A declaration of the Item a Vector is expected to hold, see Validate and
+// ApplyDefaults. A Schema transmits as a JSON Schema (draft 7), of which the
+// keywords type, format, description, properties, required, default, enum,
+// minimum, maximum, exclusiveMinimum, exclusiveMaximum, and pattern are
+// understood. A list of types, e.g. ["string", "null"], is read as the one type
+// it names other than "null", and fails with SchemaError where it names more.
+type Schema struct {
+	Fields []*Field
+}
+
+// A declaration of the Item expected at Key, a dotted path as GetPath, within
+// a Schema. Any constraint left empty is not checked. A Field of type "object"
+// may declare a nested Schema for the VectorItem or MapItem held at Key, or for
+// the keys beginning with Key.
+type Field struct {
+	Key              string
+	Type             string
+	Description      string
+	Required         bool
+	Default          interface{}
+	Enum             []interface{}
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum *float64
+	ExclusiveMaximum *float64
+	Pattern          string
+	Schema           *Schema
+}
+
+var (
+	validationFailure = xrr.Xrror("key %s fails %s: %s")
+	SchemaError       = xrr.Xrror("schema property %s is invalid: %s").Out
+)
+
+// A failure of the Item at Key to satisfy the Rule of a Field, Rule being the
+// JSON Schema keyword, e.g. "required", "type", or "minimum".
+type ValidationError struct {
+	Key     string
+	Rule    string
+	Message string
+}
+
+//
+func (e ValidationError) Error() string {
+	return validationFailure.Out(e.Key, e.Rule, e.Message).Error()
+}
+
+// Validates this Vector against the provided Schema, returning a
+// ValidationError for each failure found, in the order the Schema declares
+// them, or none.
+func (v *Vector) Validate(s *Schema) []ValidationError {
+	return s.validate(v, "")
+}
+
+func (s *Schema) validate(v *Vector, p string) []ValidationError {
+	var ret []ValidationError
+	for _, f := range s.Fields {
+		ret = append(ret, f.validate(v, p+f.Key)...)
+	}
+	return ret
+}
+
+func (f *Field) validate(v *Vector, k string) []ValidationError {
+	fail := func(rule, format string, a ...interface{}) []ValidationError {
+		return []ValidationError{{k, rule, fmt.Sprintf(format, a...)}}
+	}
+	i := v.GetPath(k)
+	if i == nil {
+		if f.Schema != nil && len(v.Prefix(k+".")) > 0 {
+			return f.Schema.validate(v, k+".")
+		}
+		if f.Required {
+			return fail("required", "no item held")
+		}
+		return nil
+	}
+	if !isType(i, f.Type) {
+		return fail("type", "holds %s, not %s", itemType(i), f.Type)
+	}
+	if len(f.Enum) > 0 && !inEnum(i, f.Enum) {
+		return fail("enum", "holds %v, not one of %v", i.Provided(), f.Enum)
+	}
+	if n, ok := numberOf(i); ok {
+		for _, b := range []struct {
+			rule  string
+			bound *float64
+			fails func(int) bool
+		}{
+			{"minimum", f.Minimum, func(c int) bool { return c < 0 }},
+			{"maximum", f.Maximum, func(c int) bool { return c > 0 }},
+			{"exclusiveMinimum", f.ExclusiveMinimum, func(c int) bool { return c <= 0 }},
+			{"exclusiveMaximum", f.ExclusiveMaximum, func(c int) bool { return c >= 0 }},
+		} {
+			if b.bound != nil && b.fails(n.Cmp(big.NewFloat(*b.bound))) {
+				return fail(b.rule, "holds %s, out of range %v", n.Text('g', -1), *b.bound)
+			}
+		}
+	}
+	if f.Pattern != "" {
+		re, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return fail("pattern", "invalid pattern %s", err)
+		}
+		if s, err := getString(k, i); err == nil && !re.MatchString(s) {
+			return fail("pattern", "holds %q, not matching %s", s, f.Pattern)
+		}
+	}
+	if f.Schema != nil {
+		return f.Schema.validate(v, k+".")
+	}
+	return nil
+}
+
+// Returns true if the provided Item is of the provided Schema type, or the
+// type is empty.
+func isType(i Item, t string) bool {
+	switch t {
+	case "":
+		return true
+	case SchemaString:
+		switch i.(type) {
+		case StringItem, TimeItem, DurationItem:
+			return true
+		}
+	case SchemaInteger:
+		n, ok := numberOf(i)
+		return ok && n.IsInt()
+	case SchemaNumber:
+		_, ok := numberOf(i)
+		return ok
+	case SchemaBoolean:
+		_, ok := i.(BoolItem)
+		return ok
+	case SchemaArray:
+		switch i.(type) {
+		case StringsItem, IntsItem, Float64sItem, BoolsItem, ListItem:
+			return true
+		}
+	case SchemaObject:
+		switch i.(type) {
+		case VectorItem, MapItem:
+			return true
+		}
+	case SchemaTime:
+		_, ok := i.(TimeItem)
+		return ok
+	case SchemaDuration:
+		_, ok := i.(DurationItem)
+		return ok
+	}
+	return false
+}
+
+// Returns the value of any numeric Item.
+func numberOf(i Item) (*big.Float, bool) {
+	if n, ok := signedOf(i); ok {
+		return new(big.Float).SetInt64(n), true
+	}
+	if n, ok := unsignedOf(i); ok {
+		return new(big.Float).SetUint64(n), true
+	}
+	switch ii := i.(type) {
+	case BigIntItem:
+		return new(big.Float).SetInt(ii.ToBigInt()), true
+	case DecimalItem:
+		return new(big.Float).SetRat(ii.ToDecimal()), true
+	case Float64Item, Float32Item, BigFloatItem:
+		f, err := getBigFloat(i.Key(), i)
+		return f, err == nil
+	}
+	return nil, false
+}
+
+func inEnum(i Item, enum []interface{}) bool {
+	b, err := json.Marshal(i.Provided())
+	if err != nil {
+		return false
+	}
+	for _, e := range enum {
+		if eb, err := json.Marshal(e); err == nil && string(eb) == string(b) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sets the Default of each Field declaring one where the provided Vector holds
+// no Item, as SetPath.
+func (s *Schema) ApplyDefaults(v *Vector) error {
+	return s.applyDefaults(v, "")
+}
+
+func (s *Schema) applyDefaults(v *Vector, p string) error {
+	for _, f := range s.Fields {
+		k := p + f.Key
+		if f.Default != nil && v.GetPath(k) == nil {
+			d, err := defaultValue(k, f)
+			if err != nil {
+				return err
+			}
+			if err := v.SetPath(k, d); err != nil {
+				return err
+			}
+		}
+		if f.Schema != nil {
+			if err := f.Schema.applyDefaults(v, k+"."); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the Default of the Field, parsed as a time or duration where the Field
+// is of either type.
+func defaultValue(k string, f *Field) (interface{}, error) {
+	d := plainRaw(f.Default)
+	s, ok := d.(string)
+	if !ok {
+		return d, nil
+	}
+	switch f.Type {
+	case SchemaTime:
+		return getTime(k, NewStringItem(k, s))
+	case SchemaDuration:
+		return getDuration(k, NewStringItem(k, s))
+	}
+	return d, nil
+}
+
+// An intermediary transmission type for a Schema or Field, as JSON Schema.
+type jsonSchema struct {
+	Schema           string                 `json:"$schema,omitempty"`
+	Type             schemaType             `json:"type,omitempty"`
+	Format           string                 `json:"format,omitempty"`
+	Description      string                 `json:"description,omitempty"`
+	Properties       map[string]*jsonSchema `json:"properties,omitempty"`
+	Required         []string               `json:"required,omitempty"`
+	Default          interface{}            `json:"default,omitempty"`
+	Enum             []interface{}          `json:"enum,omitempty"`
+	Minimum          *float64               `json:"minimum,omitempty"`
+	Maximum          *float64               `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64               `json:"exclusiveMaximum,omitempty"`
+	Pattern          string                 `json:"pattern,omitempty"`
+}
+
+const draft7 = "http://json-schema.org/draft-07/schema#"
+
+var schemaFormats = map[string]string{
+	SchemaTime:     "date-time",
+	SchemaDuration: "go-duration",
+}
+
+// A JSON Schema type, transmitted as a single type name or a list of them.
+type schemaType []string
+
+// json.Marshaler, as a single type name where only one is held.
+func (t schemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// json.Unmarshaler, from a single type name or a list of them.
+func (t *schemaType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = schemaType{s}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(t))
+}
+
+// Returns the one type named other than "null", if any.
+func (t schemaType) single(p string) (string, error) {
+	var ret string
+	for _, s := range t {
+		if s == "null" {
+			continue
+		}
+		if ret != "" {
+			return "", SchemaError(p, fmt.Sprintf("type names more than one of %v", []string(t)))
+		}
+		ret = s
+	}
+	return ret, nil
+}
+
+func newSchemaType(t string) schemaType {
+	if t == "" {
+		return nil
+	}
+	return schemaType{t}
+}
+
+func (s *Schema) toJSON() *jsonSchema {
+	ret := &jsonSchema{Type: newSchemaType(SchemaObject), Properties: make(map[string]*jsonSchema)}
+	for _, f := range s.Fields {
+		p := &jsonSchema{
+			Type:             newSchemaType(f.Type),
+			Description:      f.Description,
+			Default:          f.Default,
+			Enum:             f.Enum,
+			Minimum:          f.Minimum,
+			Maximum:          f.Maximum,
+			ExclusiveMinimum: f.ExclusiveMinimum,
+			ExclusiveMaximum: f.ExclusiveMaximum,
+			Pattern:          f.Pattern,
+		}
+		if fm, ok := schemaFormats[f.Type]; ok {
+			p.Type, p.Format = newSchemaType(SchemaString), fm
+		}
+		if f.Schema != nil {
+			n := f.Schema.toJSON()
+			p.Properties, p.Required = n.Properties, n.Required
+		}
+		if f.Required {
+			ret.Required = append(ret.Required, f.Key)
+		}
+		ret.Properties[f.Key] = p
+	}
+	return ret
+}
+
+func (s *Schema) fromJSON(p string, j *jsonSchema) error {
+	t, err := j.Type.single(p)
+	if err != nil {
+		return err
+	}
+	if t != "" && t != SchemaObject {
+		return SchemaError(p, "a schema must be of type object")
+	}
+	var keys []string
+	for k := range j.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	s.Fields = nil
+	for _, k := range keys {
+		pj := j.Properties[k]
+		pk := strings.TrimPrefix(p+"."+k, ".")
+		typ, err := pj.Type.single(pk)
+		if err != nil {
+			return err
+		}
+		var enum []interface{}
+		for _, e := range pj.Enum {
+			enum = append(enum, plainRaw(e))
+		}
+		f := &Field{
+			Key:              k,
+			Type:             typ,
+			Description:      pj.Description,
+			Required:         inList(k, j.Required),
+			Default:          plainRaw(pj.Default),
+			Enum:             enum,
+			Minimum:          pj.Minimum,
+			Maximum:          pj.Maximum,
+			ExclusiveMinimum: pj.ExclusiveMinimum,
+			ExclusiveMaximum: pj.ExclusiveMaximum,
+			Pattern:          pj.Pattern,
+		}
+		for t, fm := range schemaFormats {
+			if typ == SchemaString && pj.Format == fm {
+				f.Type = t
+			}
+		}
+		if pj.Pattern != "" {
+			if _, err := regexp.Compile(pj.Pattern); err != nil {
+				return SchemaError(pk, err)
+			}
+		}
+		if pj.Properties != nil {
+			f.Schema = &Schema{}
+			if err := f.Schema.fromJSON(pk, pj); err != nil {
+				return err
+			}
+		}
+		s.Fields = append(s.Fields, f)
+	}
+	return nil
+}
+
+// json.Marshaler, as a JSON Schema (draft 7).
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	j := s.toJSON()
+	j.Schema = draft7
+	return json.Marshal(j)
+}
+
+// json.Unmarshaler, from a JSON Schema (draft 7), each property becoming a
+// Field, in key order.
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	var j jsonSchema
+	if err := decodeJSON(b, &j); err != nil {
+		return err
+	}
+	return s.fromJSON("", &j)
+}
@@ -0,0 +1,173 @@
+package data
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func float(f float64) *float64 {
+	return &f
+}
+
+var testSchema = &Schema{Fields: []*Field{
+	{Key: "name", Type: SchemaString, Required: true, Pattern: `^[a-z]+$`},
+	{Key: "mode", Type: SchemaString, Enum: []interface{}{"dev", "prod"}, Default: "dev"},
+	{Key: "timeout", Type: SchemaDuration, Default: "30s"},
+	{Key: "db", Type: SchemaObject, Required: true, Schema: &Schema{Fields: []*Field{
+		{Key: "host", Type: SchemaString, Required: true},
+		{Key: "port", Type: SchemaInteger, Default: 5432, Minimum: float(1), Maximum: float(65535)},
+	}}},
+	{Key: "ratio", Type: SchemaNumber, ExclusiveMaximum: float(1)},
+}}
+
+func rules(errs []ValidationError) []string {
+	var ret []string
+	for _, e := range errs {
+		ret = append(ret, e.Key+":"+e.Rule)
+	}
+	return ret
+}
+
+func TestValidate(t *testing.T) {
+	v := New("SCHEMA")
+	v.SetString("name", "app")
+	v.SetString("db.host", "localhost")
+	v.SetInt("db.port", 5432)
+	v.SetFloat64("ratio", 0.5)
+	if errs := v.Validate(testSchema); len(errs) != 0 {
+		t.Errorf("expected valid vector, received %v", errs)
+	}
+
+	n := New("NESTED")
+	n.SetString("host", "localhost")
+	n.SetInt64("port", 80)
+	v.DeleteSubtree("db.")
+	v.SetVector("db", n)
+	if errs := v.Validate(testSchema); len(errs) != 0 {
+		t.Errorf("expected valid nested vector, received %v", errs)
+	}
+
+	v = New("INVALID")
+	v.SetString("name", "App")
+	v.SetString("mode", "test")
+	v.SetString("db.port", "5432")
+	v.SetFloat64("ratio", 1)
+	want := []string{"name:pattern", "mode:enum", "db.host:required", "db.port:type", "ratio:exclusiveMaximum"}
+	if got := rules(v.Validate(testSchema)); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, received %v", want, got)
+	}
+
+	v = New("MISSING")
+	v.SetMap("db", map[string]interface{}{"host": "db", "port": 70000})
+	want = []string{"name:required", "db.port:maximum"}
+	errs := v.Validate(testSchema)
+	if got := rules(errs); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, received %v", want, got)
+	}
+	if msg := errs[1].Error(); !strings.Contains(msg, "db.port") || !strings.Contains(msg, "maximum") {
+		t.Errorf("unexpected error message %q", msg)
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	v := New("DEFAULTS")
+	v.SetString("name", "app")
+	v.SetString("mode", "prod")
+	v.SetString("db.host", "localhost")
+	if err := testSchema.ApplyDefaults(v); err != nil {
+		t.Fatal(err)
+	}
+	if v.ToString("mode") != "prod" || v.ToInt("db.port") != 5432 || v.ToDuration("timeout").Seconds() != 30 {
+		t.Errorf("defaults not applied: %v", v.TemplateData())
+	}
+	if errs := v.Validate(testSchema); len(errs) != 0 {
+		t.Errorf("expected valid vector with defaults, received %v", errs)
+	}
+
+	n := New("NESTED")
+	n.SetString("host", "localhost")
+	v = New("DEFAULTS")
+	v.SetVector("db", n)
+	if err := testSchema.ApplyDefaults(v); err != nil {
+		t.Fatal(err)
+	}
+	if p := v.ToVector("db").ToInt("port"); p != 5432 {
+		t.Errorf("expected nested default, received %d", p)
+	}
+	if err := testSchema.ApplyDefaults(New("FROZEN").ReadOnly()); err != ErrFrozen {
+		t.Errorf("expected ErrFrozen, received %v", err)
+	}
+}
+
+const testJSONSchema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type": "object",
+	"required": ["name", "db"],
+	"properties": {
+		"name": {"type": "string", "pattern": "^[a-z]+$"},
+		"mode": {"type": "string", "enum": ["dev", "prod"], "default": "dev"},
+		"timeout": {"type": "string", "format": "go-duration", "default": "30s"},
+		"db": {
+			"type": "object",
+			"required": ["host"],
+			"properties": {
+				"host": {"type": "string"},
+				"port": {"type": "integer", "default": 5432, "minimum": 1, "maximum": 65535}
+			}
+		},
+		"ratio": {"type": "number", "exclusiveMaximum": 1}
+	}
+}`
+
+func TestSchemaJSON(t *testing.T) {
+	var s Schema
+	if err := json.Unmarshal([]byte(testJSONSchema), &s); err != nil {
+		t.Fatal(err)
+	}
+	v := New("JSON")
+	v.SetString("name", "App")
+	v.SetString("db.port", "5432")
+	want := []string{"db.host:required", "db.port:type", "name:pattern"}
+	if got := rules(v.Validate(&s)); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, received %v", want, got)
+	}
+	v = New("DEFAULTS")
+	if err := s.ApplyDefaults(v); err != nil {
+		t.Fatal(err)
+	}
+	if v.ToString("mode") != "dev" || v.ToInt64("db.port") != 5432 || v.ToDuration("timeout").Seconds() != 30 {
+		t.Errorf("defaults not applied: %v", v.TemplateData())
+	}
+
+	b, err := json.Marshal(testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rs Schema
+	if err := json.Unmarshal(b, &rs); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rs.toJSON(), s.toJSON()) {
+		rb, _ := json.Marshal(&rs)
+		t.Errorf("expected schema to round trip, received %s", rb)
+	}
+
+	if err := json.Unmarshal([]byte(`{"properties": {"a": {"pattern": "["}}}`), &s); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+
+	if err := json.Unmarshal([]byte(`{"type": ["object", "null"], "properties": {"a": {"type": ["string", "null"]}, "d": {"type": "string", "format": "duration"}}}`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if a, d := s.Fields[0], s.Fields[1]; a.Type != SchemaString || d.Type != SchemaString {
+		t.Errorf("expected string types, received %s, %s", a.Type, d.Type)
+	}
+	if err := json.Unmarshal([]byte(`{"properties": {"a": {"type": ["string", "integer"]}}}`), &s); err == nil {
+		t.Error("expected error for a list of several types")
+	}
+	if b, _ := json.Marshal(testSchema); !strings.Contains(string(b), `"format":"go-duration"`) {
+		t.Errorf("expected go-duration format, received %s", b)
+	}
+}
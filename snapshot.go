@@ -0,0 +1,270 @@
+package data
+
+import (
+	"math/big"
+	"time"
+)
+
+// A read-only, point in time view of a Vector. A Snapshot shares structure
+// with the Vector it was taken from; the Vector copies only the nodes of its
+// Trie on the path of each change made after a Snapshot is taken, leaving the
+// Snapshot untouched. A Snapshot may be read concurrently with writes to the
+// Vector.
+//
+// Item held by a Snapshot are shared with the Vector and should be treated as
+// read-only, unless taken from a frozen Vector, where they are copied as
+// retrieved.
+type Snapshot struct {
+	t       *Trie
+	o       []Option
+	bl      []string
+	version uint64
+	frozen  bool
+	clk     Clock
+}
+
+// Prepares for a change to the Vector, forking a Trie shared with a Snapshot
+// so that only the nodes changed are copied, and advancing the version. Must
+// be called under write lock.
+func (v *Vector) touch() {
+	if v.cow {
+		v.trie, v.cow = v.trie.fork(), false
+	}
+	v.ver++
+}
+
+// Returns the number of changes made to this Vector.
+func (v *Vector) Version() uint64 {
+	v.l.RLock()
+	defer v.l.RUnlock()
+	return v.ver
+}
+
+// Returns a read-only Snapshot of the Vector's current state.
+func (v *Vector) Snapshot() *Snapshot {
+	v.l.Lock()
+	defer v.l.Unlock()
+	v.cow = true
+	bl := make([]string, len(v.bl))
+	copy(bl, v.bl)
+	return &Snapshot{v.trie, v.o, bl, v.ver, v.frozen, v.clk}
+}
+
+// Returns the Vector version this Snapshot was taken at.
+func (s *Snapshot) Version() uint64 {
+	return s.version
+}
+
+// Returns true if the provided Vector has changed since this Snapshot was
+// taken from it.
+func (s *Snapshot) Stale(v *Vector) bool {
+	return v.Version() != s.version
+}
+
+// Returns a new Vector from this Snapshot. The new Vector shares structure with
+// the Snapshot until it is first changed, or for a Snapshot of a frozen Vector,
+// holds copies of its Item.
+func (s *Snapshot) Vector() *Vector {
+	bl := make([]string, len(s.bl))
+	copy(bl, s.bl)
+	v := &Vector{
+		o:    s.o,
+		bl:   bl,
+		ver:  s.version,
+		cow:  true,
+		clk:  s.clk,
+		trie: s.t,
+	}
+	if s.frozen {
+		v.trie, v.cow = NewTrie(s.o...), false
+		s.t.walk(nil, func(_ Prefix, i Item) error {
+			v.put(copyItem(i, false), true)
+			return nil
+		})
+	}
+	v.mutexSet()
+	v.watchSet()
+	return v
+}
+
+//
+func (s *Snapshot) Tag() string {
+	return s.ToString("vector.tag")
+}
+
+//
+func (s *Snapshot) Get(k string) Item {
+	if i := s.t.get(Prefix(k)); i != nil {
+		if s.clk.expired(i) {
+			return nil
+		}
+		return s.out(i)
+	}
+	return indexed(k, s.Get)
+}
+
+//
+func (s *Snapshot) Keys() []string {
+	var ret []string
+	s.t.walk(nil, func(p Prefix, i Item) error {
+		if !s.clk.expired(i) {
+			ret = append(ret, string(p))
+		}
+		return nil
+	})
+	return ret
+}
+
+// Returns a list of Item, EXCEPT those matching the provided key strings.
+func (s *Snapshot) List(except ...string) []Item {
+	var ret []Item
+	s.t.walk(nil, func(p Prefix, i Item) error {
+		if !match(except, i.Key()) && !s.clk.expired(i) {
+			ret = append(ret, s.out(i))
+		}
+		return nil
+	})
+	return ret
+}
+
+func (s *Snapshot) out(i Item) Item {
+	if s.frozen {
+		return copyItem(i, true)
+	}
+	return i
+}
+
+// Return a string from key matching a stored StringItem.
+func (s *Snapshot) ToString(k string) string {
+	return toString(s.Get(k))
+}
+
+// Return an array of strings from a matching key.
+func (s *Snapshot) ToStrings(k string) []string {
+	return toStrings(s.Get(k))
+}
+
+// Return a boolean from a matching key.
+func (s *Snapshot) ToBool(k string) bool {
+	return toBool(s.Get(k))
+}
+
+// Return an integer from a matching key.
+func (s *Snapshot) ToInt(k string) int {
+	return toInt(s.Get(k))
+}
+
+// Return an int64 from a matching key.
+func (s *Snapshot) ToInt64(k string) int64 {
+	return toInt64(s.Get(k))
+}
+
+// Return an uint from a matching key.
+func (s *Snapshot) ToUint(k string) uint {
+	return toUint(s.Get(k))
+}
+
+// Return an uint64 from a matching key.
+func (s *Snapshot) ToUint64(k string) uint64 {
+	return toUint64(s.Get(k))
+}
+
+// Return a float64 from a matching key.
+func (s *Snapshot) ToFloat64(k string) float64 {
+	return toFloat64(s.Get(k))
+}
+
+// Return a time.Time from a matching key.
+func (s *Snapshot) ToTime(k string) time.Time {
+	return toTime(s.Get(k))
+}
+
+// Return a time.Duration from a matching key.
+func (s *Snapshot) ToDuration(k string) time.Duration {
+	return toDuration(s.Get(k))
+}
+
+// Return a *Vector from a key matching a stored VectorItem.
+func (s *Snapshot) ToVector(k string) *Vector {
+	return toVector(s.Get(k))
+}
+
+// Return a map from a key matching a stored MapItem.
+func (s *Snapshot) ToMap(k string) map[string]interface{} {
+	return toMap(s.Get(k))
+}
+
+// Return a []byte from a key matching a stored BytesItem.
+func (s *Snapshot) ToBytes(k string) []byte {
+	return toBytes(s.Get(k))
+}
+
+// Return an int8 from a key matching a stored Int8Item.
+func (s *Snapshot) ToInt8(k string) int8 {
+	return toInt8(s.Get(k))
+}
+
+// Return an int16 from a key matching a stored Int16Item.
+func (s *Snapshot) ToInt16(k string) int16 {
+	return toInt16(s.Get(k))
+}
+
+// Return an int32 from a key matching a stored Int32Item.
+func (s *Snapshot) ToInt32(k string) int32 {
+	return toInt32(s.Get(k))
+}
+
+// Return an uint8 from a key matching a stored Uint8Item.
+func (s *Snapshot) ToUint8(k string) uint8 {
+	return toUint8(s.Get(k))
+}
+
+// Return an uint16 from a key matching a stored Uint16Item.
+func (s *Snapshot) ToUint16(k string) uint16 {
+	return toUint16(s.Get(k))
+}
+
+// Return an uint32 from a key matching a stored Uint32Item.
+func (s *Snapshot) ToUint32(k string) uint32 {
+	return toUint32(s.Get(k))
+}
+
+// Return a float32 from a key matching a stored Float32Item.
+func (s *Snapshot) ToFloat32(k string) float32 {
+	return toFloat32(s.Get(k))
+}
+
+// Return a *big.Int from a key matching a stored BigIntItem.
+func (s *Snapshot) ToBigInt(k string) *big.Int {
+	return toBigInt(s.Get(k))
+}
+
+// Return a *big.Float from a key matching a stored BigFloatItem.
+func (s *Snapshot) ToBigFloat(k string) *big.Float {
+	return toBigFloat(s.Get(k))
+}
+
+// Return a *big.Rat from a key matching a stored DecimalItem.
+func (s *Snapshot) ToDecimal(k string) *big.Rat {
+	return toDecimal(s.Get(k))
+}
+
+// Return an array of integers from a key matching a stored IntsItem.
+func (s *Snapshot) ToInts(k string) []int {
+	return toInts(s.Get(k))
+}
+
+// Return an array of float64 from a key matching a stored Float64sItem.
+func (s *Snapshot) ToFloat64s(k string) []float64 {
+	return toFloat64s(s.Get(k))
+}
+
+// Return an array of booleans from a key matching a stored BoolsItem.
+func (s *Snapshot) ToBools(k string) []bool {
+	return toBools(s.Get(k))
+}
+
+// Return an array of any values from a key matching a stored ListItem.
+func (s *Snapshot) ToList(k string) []interface{} {
+	return toList(s.Get(k))
+}
@@ -0,0 +1,126 @@
+package data
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	v := base.Clone()
+	s := v.Snapshot()
+	if s.Stale(v) {
+		t.Error("snapshot stale before any change")
+	}
+	kl := len(s.Keys())
+
+	v.SetInt("a.int", 100)
+	v.SetString("b.new", "new")
+	v.Delete("a.string")
+	v.Retag("RETAGGED")
+
+	if !s.Stale(v) {
+		t.Error("snapshot not stale after change")
+	}
+	if i := s.ToInt("a.int"); i != 9 {
+		t.Errorf("snapshot int changed to %d", i)
+	}
+	if s.Get("b.new") != nil || s.Get("a.string") == nil {
+		t.Error("snapshot items changed with vector")
+	}
+	if l := len(s.Keys()); l != kl {
+		t.Errorf("snapshot key length changed from %d to %d", kl, l)
+	}
+	if tag := s.Tag(); tag == "RETAGGED" {
+		t.Error("snapshot tag changed with vector")
+	}
+
+	n := s.Vector()
+	n.SetInt("a.int", 200)
+	if s.ToInt("a.int") != 9 || v.ToInt("a.int") != 100 {
+		t.Error("change to vector from snapshot is visible elsewhere")
+	}
+	if n.ToString("a.string") != "string" {
+		t.Error("vector from snapshot missing snapshot items")
+	}
+}
+
+func TestSnapshotConcurrent(t *testing.T) {
+	v := New("CONCURRENT")
+	for n := 0; n < 100; n++ {
+		v.SetInt(strconv.Itoa(n), n)
+	}
+	s := v.Snapshot()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 100; n++ {
+			v.SetInt(strconv.Itoa(n), -n)
+			v.Delete(strconv.Itoa(n / 2))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 100; n++ {
+			if i := s.ToInt(strconv.Itoa(n)); i != n {
+				t.Errorf("snapshot value for %d changed to %d", n, i)
+			}
+			s.Keys()
+		}
+	}()
+	wg.Wait()
+	if s.Version() >= v.Version() {
+		t.Errorf("vector version %d not advanced past snapshot version %d", v.Version(), s.Version())
+	}
+}
+
+func trieNodes(t *Trie, m map[*Trie]bool) map[*Trie]bool {
+	m[t] = true
+	var cl []*Trie
+	switch l := t.children.(type) {
+	case *sparseChildList:
+		cl = l.children
+	case *denseChildList:
+		cl = l.children
+	}
+	for _, c := range cl {
+		if c != nil {
+			trieNodes(c, m)
+		}
+	}
+	return m
+}
+
+func TestSnapshotSharing(t *testing.T) {
+	v := New("SHARING")
+	for n := 0; n < 1000; n++ {
+		v.SetInt("key."+strconv.Itoa(n), n)
+	}
+	s := v.Snapshot()
+	v.SetInt("key.500", -500)
+	v.Delete("key.10")
+	v.SetInt("key.new", 1)
+
+	before, after := trieNodes(s.t, map[*Trie]bool{}), trieNodes(v.trie, map[*Trie]bool{})
+	var copied int
+	for n := range after {
+		if !before[n] {
+			copied++
+		}
+	}
+	if copied == 0 || copied > 20 {
+		t.Errorf("%d of %d nodes copied after a snapshot", copied, len(after))
+	}
+	for n := 0; n < 1000; n++ {
+		if i := s.ToInt("key." + strconv.Itoa(n)); i != n {
+			t.Errorf("snapshot value for %d changed to %d", n, i)
+		}
+	}
+	if v.ToInt("key.500") != -500 || v.Get("key.10") != nil || v.ToInt("key.new") != 1 {
+		t.Error("changes after a snapshot not held by the vector")
+	}
+	if l := len(s.Keys()); l != 1001 {
+		t.Errorf("snapshot holds %d keys, not 1001", l)
+	}
+}
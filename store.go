@@ -11,7 +11,7 @@ import (
 	"strings"
 
 	"github.com/Laughs-In-Flowers/xrr"
-	yaml "gopkg.in/yaml.v2"
+	yaml "gopkg.in/yaml.v3"
 )
 
 //
@@ -87,10 +87,19 @@ func (s *store) In() (*Vector, error) {
 	if err != nil {
 		return nil, err
 	}
+	src := s.RetrievalString()
+	if f, ok := r.(*os.File); ok {
+		src = fmt.Sprintf("%s:%s", s.Retrieval()[0], f.Name())
+	}
 	c, err := s.ifn(s.RetrievalString(), n, r)
 	if err != nil {
 		return nil, err
 	}
+	for _, i := range c.List() {
+		if m := i.Meta(); m.Source == "" {
+			m.Source = src
+		}
+	}
 	s.Swap(c)
 	return c, nil
 }
@@ -213,7 +222,7 @@ func yamlStore(rs []string) Store {
 		},
 		func(c *Vector, w io.WriteCloser) ([]string, error) {
 			retrieval := c.ToStrings("store.retrieval.string")
-			y, err := yaml.Marshal(&c)
+			y, err := marshalYAML(&c)
 			if err != nil {
 				return nil, err
 			}
@@ -226,6 +235,21 @@ func yamlStore(rs []string) Store {
 	)
 }
 
+// Returns the provided value as yaml, indented by two spaces as written by
+// gopkg.in/yaml.v2.
+func marshalYAML(vi interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	e := yaml.NewEncoder(&b)
+	e.SetIndent(2)
+	if err := e.Encode(vi); err != nil {
+		return nil, err
+	}
+	if err := e.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
 /*
 var TomlStore = &StoreMaker{"toml", tomlStore}
 
@@ -262,14 +286,16 @@ func tomlStore(rs []string) Store {
 */
 
 var (
-	JsonStore  = &StoreMaker{"json", JsonStorer(regular)}
+	JsonStore  = &StoreMaker{"json", jsonStorer(streamed)}
 	JsonFStore = &StoreMaker{"jsonf", JsonStorer(indented)}
 )
 
 type jsonMarshaler func(*Vector) ([]byte, error)
 
-func regular(c *Vector) ([]byte, error) {
-	return json.Marshal(&c)
+type jsonEncoder func(*Vector, io.Writer) error
+
+func streamed(c *Vector, w io.Writer) error {
+	return c.EncodeJSON(w)
 }
 
 func indented(c *Vector) ([]byte, error) {
@@ -277,6 +303,17 @@ func indented(c *Vector) ([]byte, error) {
 }
 
 func JsonStorer(jm jsonMarshaler) StoreFn {
+	return jsonStorer(func(c *Vector, w io.Writer) error {
+		j, err := jm(c)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(j)
+		return err
+	})
+}
+
+func jsonStorer(je jsonEncoder) StoreFn {
 	return func(rs []string) Store {
 		return NewStore(
 			readCloserFrom("json"),
@@ -295,11 +332,7 @@ func JsonStorer(jm jsonMarshaler) StoreFn {
 			},
 			func(c *Vector, w io.WriteCloser) ([]string, error) {
 				retrieval := c.ToStrings("store.retrieval.string")
-				j, err := jm(c)
-				if err != nil {
-					return nil, err
-				}
-				_, err = w.Write(j)
+				err := je(c, w)
 				w.Close()
 				return retrieval, err
 			},
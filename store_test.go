@@ -1,8 +1,15 @@
 package data
 
 import (
+	"math"
+	"math/big"
+	"net"
+	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestStore(t *testing.T) {
@@ -197,3 +204,96 @@ func TestCustomStore(t *testing.T) {
 		t.Errorf("custom store read value is not 77: it is %d with error %s", n, err.Error())
 	}
 }
+
+func TestStoreTypes(t *testing.T) {
+	now := time.Now().UTC()
+	n := New("nested")
+	n.SetUint64("n.uint64", math.MaxUint64)
+	in := New("TYPES")
+	in.Set(
+		NewStringItem("t.string", "string"),
+		NewStringsItem("t.strings", "a", "b"),
+		NewBoolItem("t.bool", true),
+		NewIntItem("t.int", -9),
+		NewInt64Item("t.int64", math.MinInt64),
+		NewUintItem("t.uint", 9),
+		NewUint64Item("t.uint64", math.MaxUint64),
+		NewFloat64Item("t.float64", 1),
+		NewTimeItem("t.time", now),
+		NewDurationItem("t.duration", time.Second),
+		NewVectorItem("t.vector", n),
+		NewBytesItem("t.bytes", []byte{0x00, 0xff}),
+		NewIntsItem("t.ints", 1, 2),
+		NewFloat64sItem("t.float64s", 0.5),
+		NewBoolsItem("t.bools", true),
+		NewListItem("t.list", "a", 1, 2.5, false, []interface{}{int64(1)}),
+		NewInt8Item("t.int8", math.MinInt8),
+		NewUint32Item("t.uint32", math.MaxUint32),
+		NewFloat32Item("t.float32", 0.5),
+		NewBigIntItem("t.bigint", new(big.Int).Lsh(big.NewInt(1), 100)),
+		NewBigFloatItem("t.bigfloat", big.NewFloat(0.1)),
+		NewDecimalItem("t.decimal", big.NewRat(1, 8)),
+		NewMapItem("t.map", map[string]interface{}{
+			"a": "a",
+			"b": map[string]interface{}{"c": true, "d": []interface{}{"d"}},
+		}),
+	)
+
+	for _, k := range []string{"json", "jsonf", "yaml"} {
+		trs := []string{k, currentDir, "types"}
+		in.SetStrings("store.retrieval.string", trs...)
+		s, _ := GetStore(k, trs)
+		s.Swap(in)
+		if _, err := s.Out(); err != nil {
+			t.Fatal(err)
+		}
+		out, err := s.In()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, i := range in.List() {
+			o := out.Get(i.Key())
+			if reflect.TypeOf(o) != reflect.TypeOf(i) {
+				t.Errorf("%s store: %s restored as %T, not %T", k, i.Key(), o, i)
+			}
+		}
+		if p := Diff(in, out); len(p) != 0 {
+			t.Errorf("%s store: restored vector differs: %v", k, p)
+		}
+		if u := out.ToVector("t.vector").ToUint64("n.uint64"); u != math.MaxUint64 {
+			t.Errorf("%s store: nested uint64 restored as %d", k, u)
+		}
+		os.Remove(filepath.Join(currentDir, "types."+k))
+	}
+	os.Remove(filepath.Join(currentDir, "types.json"))
+}
+
+func TestStoreRegisteredTypes(t *testing.T) {
+	u, _ := url.Parse("https://example.com")
+	in := New("REGISTERED")
+	in.Set(
+		NewTypedItem("r.ip", net.ParseIP("::1")),
+		newURLItem("r.url", u),
+	)
+
+	for _, k := range []string{"json", "jsonf", "yaml"} {
+		trs := []string{k, currentDir, "registered"}
+		in.SetStrings("store.retrieval.string", trs...)
+		s, _ := GetStore(k, trs)
+		s.Swap(in)
+		if _, err := s.Out(); err != nil {
+			t.Fatal(err)
+		}
+		out, err := s.In()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, key := range []string{"r.ip", "r.url"} {
+			if o, i := out.Get(key), in.Get(key); reflect.TypeOf(o) != reflect.TypeOf(i) {
+				t.Errorf("%s store: %s restored as %T, not %T", k, key, o, i)
+			}
+		}
+		os.Remove(filepath.Join(currentDir, "registered."+k))
+	}
+	os.Remove(filepath.Join(currentDir, "registered.json"))
+}
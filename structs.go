@@ -0,0 +1,512 @@
+package data
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Laughs-In-Flowers/xrr"
+)
+
+// The struct tag key read by Decode and FromStruct, e.g.
+//
+//	Host string `data:"db.host"`
+//
+// An untagged field uses its lower cased name as key, a "-" tag skips the
+// field. Nested struct fields are keyed with dots beneath the key of their
+// parent, unless tagged with the "vector" option, e.g. `data:"db,vector"`, in
+// which case they are held as a VectorItem.
+const StructTag = "data"
+
+// An error decoding or encoding a single struct field.
+type FieldError struct {
+	Field string
+	Key   string
+	Err   error
+}
+
+//
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s (key %s): %s", e.Field, e.Key, e.Err)
+}
+
+// Every FieldError met decoding or encoding a struct.
+type FieldErrors []*FieldError
+
+//
+func (e FieldErrors) Error() string {
+	var s []string
+	for _, fe := range e {
+		s = append(s, fe.Error())
+	}
+	return strings.Join(s, "; ")
+}
+
+func (e FieldErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+var (
+	DecodeTargetError    = xrr.Xrror("decode target %T is not a non-nil pointer to a struct").Out
+	EncodeSourceError    = xrr.Xrror("encode source %T is not a struct or pointer to a struct").Out
+	ConversionError      = xrr.Xrror("cannot convert %T to %s").Out
+	OverflowError        = xrr.Xrror("value %v overflows %s").Out
+	UnsupportedTypeError = xrr.Xrror("unsupported type %s").Out
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+type structField struct {
+	name   string
+	key    string
+	vector bool
+	embed  bool
+}
+
+func fieldOf(sf reflect.StructField) (structField, bool) {
+	if sf.PkgPath != "" {
+		return structField{}, false
+	}
+	tag := sf.Tag.Get(StructTag)
+	if tag == "-" {
+		return structField{}, false
+	}
+	opts := strings.Split(tag, ",")
+	f := structField{name: sf.Name, key: opts[0]}
+	for _, o := range opts[1:] {
+		if o == "vector" {
+			f.vector = true
+		}
+	}
+	f.embed = sf.Anonymous && f.key == "" && isStruct(sf.Type)
+	if f.key == "" {
+		f.key = strings.ToLower(sf.Name)
+	}
+	return f, true
+}
+
+func isStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	p := reflect.PtrTo(t)
+	return !p.Implements(textUnmarshalerType) && !p.Implements(textMarshalerType)
+}
+
+func dotted(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func alloc(fv reflect.Value) reflect.Value {
+	for fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		fv = fv.Elem()
+	}
+	return fv
+}
+
+// Decodes this Vector into the struct pointed to by dst, following StructTag
+// struct tags. Fields without a matching key are left unchanged; a FieldErrors
+// is returned for every field that could not be set from its Item.
+func (v *Vector) Decode(dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return DecodeTargetError(dst)
+	}
+	var errs FieldErrors
+	v.decodeStruct(rv.Elem(), "", "", &errs)
+	return errs.err()
+}
+
+func (v *Vector) hasSubtree(prefix string) bool {
+	v.l.RLock()
+	defer v.l.RUnlock()
+	return v.MatchSubtree(Prefix(prefix + "."))
+}
+
+func (v *Vector) decodeStruct(rv reflect.Value, prefix, path string, errs *FieldErrors) {
+	rt := rv.Type()
+	for n := 0; n < rt.NumField(); n++ {
+		f, ok := fieldOf(rt.Field(n))
+		if !ok {
+			continue
+		}
+		fv := rv.Field(n)
+		fp := dotted(path, f.name)
+		if f.embed {
+			v.decodeStruct(alloc(fv), prefix, path, errs)
+			continue
+		}
+		key := dotted(prefix, f.key)
+		i := v.Get(key)
+		if isStruct(fv.Type()) {
+			if nv := heldVector(i); nv != nil {
+				nv.decodeStruct(alloc(fv), "", fp, errs)
+			} else if v.hasSubtree(key) {
+				v.decodeStruct(alloc(fv), key, fp, errs)
+			}
+			continue
+		}
+		if i == nil {
+			continue
+		}
+		if err := setValue(alloc(fv), i.Provided()); err != nil {
+			*errs = append(*errs, &FieldError{fp, key, err})
+		}
+	}
+}
+
+func setValue(fv reflect.Value, raw interface{}) error {
+	if rv := reflect.ValueOf(raw); rv.IsValid() && rv.Type().AssignableTo(fv.Type()) {
+		fv.Set(rv)
+		return nil
+	}
+
+	if fv.CanAddr() {
+		if u, ok := fv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			s, ok := raw.(string)
+			if !ok {
+				return ConversionError(raw, fv.Type())
+			}
+			return u.UnmarshalText([]byte(s))
+		}
+	}
+
+	if fv.Type() == durationType {
+		if s, ok := raw.(string); ok {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return err
+			}
+			fv.SetInt(int64(d))
+			return nil
+		}
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		s, ok := raw.(string)
+		if !ok {
+			return ConversionError(raw, fv.Type())
+		}
+		fv.SetString(s)
+	case reflect.Bool:
+		switch r := raw.(type) {
+		case bool:
+			fv.SetBool(r)
+		case string:
+			b, err := strconv.ParseBool(r)
+			if err != nil {
+				return err
+			}
+			fv.SetBool(b)
+		default:
+			return ConversionError(raw, fv.Type())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := rawInt(raw)
+		if err != nil {
+			return err
+		}
+		if fv.OverflowInt(n) {
+			return OverflowError(n, fv.Type())
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := rawUint(raw)
+		if err != nil {
+			return err
+		}
+		if fv.OverflowUint(n) {
+			return OverflowError(n, fv.Type())
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := rawFloat(raw)
+		if err != nil {
+			return err
+		}
+		if fv.OverflowFloat(n) {
+			return OverflowError(n, fv.Type())
+		}
+		fv.SetFloat(n)
+	case reflect.Slice:
+		rs := reflect.ValueOf(raw)
+		if rs.Kind() != reflect.Slice {
+			return ConversionError(raw, fv.Type())
+		}
+		sl := reflect.MakeSlice(fv.Type(), rs.Len(), rs.Len())
+		for n := 0; n < rs.Len(); n++ {
+			if err := setValue(sl.Index(n), rs.Index(n).Interface()); err != nil {
+				return fmt.Errorf("index %d: %s", n, err)
+			}
+		}
+		fv.Set(sl)
+	default:
+		return UnsupportedTypeError(fv.Type())
+	}
+	return nil
+}
+
+func rawInt(raw interface{}) (int64, error) {
+	rv := reflect.ValueOf(raw)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > 1<<63-1 {
+			return 0, OverflowError(raw, "int64")
+		}
+		return int64(rv.Uint()), nil
+	}
+	switch r := raw.(type) {
+	case float32:
+		return rawInt(float64(r))
+	case float64:
+		if r != float64(int64(r)) {
+			return 0, ConversionError(raw, "int64")
+		}
+		return int64(r), nil
+	case json.Number:
+		return strconv.ParseInt(r.String(), 10, 64)
+	case string:
+		return strconv.ParseInt(r, 10, 64)
+	}
+	return 0, ConversionError(raw, "int64")
+}
+
+func rawUint(raw interface{}) (uint64, error) {
+	rv := reflect.ValueOf(raw)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() < 0 {
+			return 0, OverflowError(raw, "uint64")
+		}
+		return uint64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), nil
+	}
+	switch r := raw.(type) {
+	case float32:
+		return rawUint(float64(r))
+	case float64:
+		if r < 0 || r != float64(uint64(r)) {
+			return 0, ConversionError(raw, "uint64")
+		}
+		return uint64(r), nil
+	case json.Number:
+		return strconv.ParseUint(r.String(), 10, 64)
+	case string:
+		return strconv.ParseUint(r, 10, 64)
+	}
+	return 0, ConversionError(raw, "uint64")
+}
+
+func rawFloat(raw interface{}) (float64, error) {
+	rv := reflect.ValueOf(raw)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	}
+	switch r := raw.(type) {
+	case float32:
+		return float64(r), nil
+	case float64:
+		return r, nil
+	case json.Number:
+		return r.Float64()
+	case string:
+		return strconv.ParseFloat(r, 64)
+	}
+	return 0, ConversionError(raw, "float64")
+}
+
+// Returns a new Vector with the provided tag holding an Item for every field
+// of the struct src, following StructTag struct tags. Nil pointer fields are
+// skipped; a FieldErrors is returned for every field that could not be held.
+func FromStruct(tag string, src interface{}) (*Vector, error) {
+	rv := reflect.ValueOf(src)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, EncodeSourceError(src)
+	}
+	var errs FieldErrors
+	v := New(tag)
+	v.Set(encodeStruct(rv, "", "", &errs)...)
+	return v, errs.err()
+}
+
+func encodeStruct(rv reflect.Value, prefix, path string, errs *FieldErrors) []Item {
+	var ret []Item
+	rt := rv.Type()
+	for n := 0; n < rt.NumField(); n++ {
+		f, ok := fieldOf(rt.Field(n))
+		if !ok {
+			continue
+		}
+		fv := rv.Field(n)
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Ptr {
+			continue
+		}
+		fp := dotted(path, f.name)
+		if f.embed {
+			ret = append(ret, encodeStruct(fv, prefix, path, errs)...)
+			continue
+		}
+		key := dotted(prefix, f.key)
+		if isStruct(fv.Type()) {
+			if f.vector {
+				nv := New(f.key)
+				nv.Set(encodeStruct(fv, "", fp, errs)...)
+				ret = append(ret, NewVectorItem(key, nv))
+				continue
+			}
+			ret = append(ret, encodeStruct(fv, key, fp, errs)...)
+			continue
+		}
+		i, err := toItem(key, fv)
+		if err != nil {
+			*errs = append(*errs, &FieldError{fp, key, err})
+			continue
+		}
+		ret = append(ret, i)
+	}
+	return ret
+}
+
+func toItem(k string, fv reflect.Value) (Item, error) {
+	switch v := fv.Interface().(type) {
+	case time.Time:
+		return NewTimeItem(k, v), nil
+	case time.Duration:
+		return NewDurationItem(k, v), nil
+	case *big.Int:
+		return NewBigIntItem(k, v), nil
+	case *big.Float:
+		return NewBigFloatItem(k, v), nil
+	case *big.Rat:
+		return NewDecimalItem(k, v), nil
+	}
+
+	if fv.Type().Implements(textMarshalerType) {
+		s, err := valueString(fv)
+		if err != nil {
+			return nil, err
+		}
+		return NewStringItem(k, s), nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		return NewStringItem(k, fv.String()), nil
+	case reflect.Bool:
+		return NewBoolItem(k, fv.Bool()), nil
+	case reflect.Int:
+		return NewIntItem(k, int(fv.Int())), nil
+	case reflect.Int8:
+		return NewInt8Item(k, int8(fv.Int())), nil
+	case reflect.Int16:
+		return NewInt16Item(k, int16(fv.Int())), nil
+	case reflect.Int32:
+		return NewInt32Item(k, int32(fv.Int())), nil
+	case reflect.Int64:
+		return NewInt64Item(k, fv.Int()), nil
+	case reflect.Uint:
+		return NewUintItem(k, uint(fv.Uint())), nil
+	case reflect.Uint8:
+		return NewUint8Item(k, uint8(fv.Uint())), nil
+	case reflect.Uint16:
+		return NewUint16Item(k, uint16(fv.Uint())), nil
+	case reflect.Uint32:
+		return NewUint32Item(k, uint32(fv.Uint())), nil
+	case reflect.Uint64:
+		return NewUint64Item(k, fv.Uint()), nil
+	case reflect.Float32:
+		return NewFloat32Item(k, float32(fv.Float())), nil
+	case reflect.Float64:
+		return NewFloat64Item(k, fv.Float()), nil
+	case reflect.Slice:
+		return sliceItem(k, fv)
+	}
+	return nil, UnsupportedTypeError(fv.Type())
+}
+
+// Returns a BytesItem for a slice of bytes, an IntsItem, Float64sItem, or
+// BoolsItem for a slice of int, float64, or bool, or otherwise a StringsItem of
+// the text of each element.
+func sliceItem(k string, fv reflect.Value) (Item, error) {
+	if et := fv.Type().Elem(); !et.Implements(textMarshalerType) {
+		switch et.Kind() {
+		case reflect.Uint8:
+			return NewBytesItem(k, append([]byte(nil), fv.Bytes()...)), nil
+		case reflect.Int:
+			l := make([]int, fv.Len())
+			for n := range l {
+				l[n] = int(fv.Index(n).Int())
+			}
+			return NewIntsItem(k, l...), nil
+		case reflect.Float64:
+			l := make([]float64, fv.Len())
+			for n := range l {
+				l[n] = fv.Index(n).Float()
+			}
+			return NewFloat64sItem(k, l...), nil
+		case reflect.Bool:
+			l := make([]bool, fv.Len())
+			for n := range l {
+				l[n] = fv.Index(n).Bool()
+			}
+			return NewBoolsItem(k, l...), nil
+		}
+	}
+	var l []string
+	for n := 0; n < fv.Len(); n++ {
+		s, err := valueString(fv.Index(n))
+		if err != nil {
+			return nil, fmt.Errorf("index %d: %s", n, err)
+		}
+		l = append(l, s)
+	}
+	return NewStringsItem(k, l...), nil
+}
+
+func valueString(fv reflect.Value) (string, error) {
+	if m, ok := fv.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	switch fv.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16,
+		reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return fmt.Sprint(fv.Interface()), nil
+	}
+	return "", UnsupportedTypeError(fv.Type())
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+type testDB struct {
+	Host    string        `data:"host"`
+	Port    int           `data:"port"`
+	Timeout time.Duration `data:"timeout"`
+	Replica *testDB       `data:"replica"`
+}
+
+type testConfig struct {
+	Name    string
+	Debug   bool          `data:"app.debug"`
+	Ratio   float64       `data:"app.ratio"`
+	Hosts   []string      `data:"app.hosts"`
+	Ports   []uint16      `data:"app.ports"`
+	Blob    []byte        `data:"app.blob"`
+	Counts  []int         `data:"app.counts"`
+	Weights []float64     `data:"app.weights"`
+	Flags   []bool        `data:"app.flags"`
+	Addr    net.IP        `data:"app.addr"`
+	DB      testDB        `data:"db"`
+	Cache   *testDB       `data:"cache,vector"`
+	Missing *testDB       `data:"missing"`
+	Ignored string        `data:"-"`
+	Wait    time.Duration `data:"app.wait"`
+}
+
+func TestStructs(t *testing.T) {
+	src := &testConfig{
+		Name:    "name",
+		Debug:   true,
+		Ratio:   0.5,
+		Hosts:   []string{"a", "b"},
+		Ports:   []uint16{80, 443},
+		Blob:    []byte("blob"),
+		Counts:  []int{1, 2},
+		Weights: []float64{0.5},
+		Flags:   []bool{true, false},
+		Addr:    net.ParseIP("10.0.0.1"),
+		DB: testDB{
+			Host:    "localhost",
+			Port:    5432,
+			Timeout: 3 * time.Second,
+			Replica: &testDB{Host: "replica"},
+		},
+		Cache:   &testDB{Host: "cache", Port: 6379},
+		Ignored: "ignored",
+		Wait:    time.Minute,
+	}
+	v, err := FromStruct("STRUCT", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.ToString("db.host") != "localhost" || v.ToInt("db.port") != 5432 || v.ToString("db.replica.host") != "replica" {
+		t.Errorf("struct not encoded to dotted keys: %v", v.Keys())
+	}
+	if c := v.ToVector("cache"); c == nil || c.ToString("host") != "cache" {
+		t.Errorf("struct not encoded to vector item: %v", c)
+	}
+	if v.Get("ignored") != nil || v.Get("missing.host") != nil {
+		t.Error("ignored or nil fields encoded")
+	}
+	if string(v.ToBytes("app.blob")) != "blob" || v.ToInts("app.counts")[1] != 2 || v.ToFloat64s("app.weights")[0] != 0.5 || !v.ToBools("app.flags")[0] {
+		t.Errorf("slices not encoded to typed items: %v", v.TemplateData())
+	}
+
+	var dst testConfig
+	if err := v.Decode(&dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "name" || !dst.Debug || dst.Ratio != 0.5 || len(dst.Hosts) != 2 || dst.Ports[1] != 443 {
+		t.Errorf("decoded values incorrect: %+v", dst)
+	}
+	if !dst.Addr.Equal(src.Addr) || dst.Wait != time.Minute || dst.DB.Timeout != 3*time.Second {
+		t.Errorf("decoded text or duration values incorrect: %+v", dst)
+	}
+	if dst.DB.Replica == nil || dst.DB.Replica.Host != "replica" || dst.Cache == nil || dst.Cache.Port != 6379 {
+		t.Errorf("decoded nested values incorrect: %+v", dst)
+	}
+	if string(dst.Blob) != "blob" || dst.Counts[1] != 2 || dst.Weights[0] != 0.5 || !dst.Flags[0] || dst.Flags[1] {
+		t.Errorf("decoded typed slices incorrect: %+v", dst)
+	}
+	if dst.Missing != nil || dst.Ignored != "" {
+		t.Errorf("decoded missing or ignored values: %+v", dst)
+	}
+
+	v.SetString("db.port", "not a port")
+	v.SetInt("app.ratio", 1)
+	v.SetInt("app.debug", 1)
+	v.SetStrings("app.ports", "80", "70000")
+	err = v.Decode(&dst)
+	fe, ok := err.(FieldErrors)
+	if !ok || len(fe) != 3 {
+		t.Fatalf("expected 3 field errors, received %v", err)
+	}
+	if fe[0].Field != "Debug" || fe[2].Key != "db.port" {
+		t.Errorf("unexpected field errors: %v", fe)
+	}
+
+	if err := v.Decode(dst); err == nil {
+		t.Error("expected error decoding to non-pointer")
+	}
+}
@@ -23,8 +23,13 @@ type Trie struct {
 	maxChildrenPerSparseNode int
 	children                 childList
 	item                     Item
+	own                      *owner
 }
 
+// Marks the nodes a Trie may change in place, any other node being shared with
+// a fork and copied before change.
+type owner struct{ _ byte }
+
 const (
 	DefaultMaxPrefixPerNode         = 10
 	DefaultMaxChildrenPerSparseNode = 8
@@ -214,6 +219,12 @@ func (t *Trie) Delete(p Prefix) bool {
 		return false
 	}
 
+	path = t.claimPath(path)
+	node = path[len(path)-1]
+	if parent != nil {
+		parent = path[len(path)-2]
+	}
+
 	// Delete the item.
 	node.item = nil
 
@@ -266,10 +277,10 @@ Compact:
 	// so try to compact since that might be possible now.
 	if compacted := node.compact(); compacted != node {
 		if parent == nil {
-			*node = *compacted
+			*node = *t.adopt(compacted)
 		} else {
 			parent.children.replace(node.prefix[0], compacted)
-			*parent = *parent.compact()
+			*parent = *t.adopt(parent.compact())
 		}
 	}
 
@@ -289,19 +300,21 @@ func (t *Trie) DeleteSubtree(p Prefix) bool {
 	}
 
 	// Locate the relevant subtree.
-	parent, root, found, _ := t.findSubtree(p)
+	path, found, _ := t.findSubtreePath(p)
 	if !found {
 		return false
 	}
 
 	// If we are in the root of the trie, reset the trie.
-	if parent == nil {
-		root.reset()
+	if len(path) == 1 {
+		path[0].reset()
 		return true
 	}
 
 	// Otherwise remove the root node from its parent.
-	parent.children.remove(root.prefix[0])
+	root := path[len(path)-1]
+	path = t.claimPath(path[:len(path)-1])
+	path[len(path)-1].children.remove(root.prefix[0])
 	return true
 }
 
@@ -326,6 +339,7 @@ func (t *Trie) total() int {
 
 func (t *Trie) reset() {
 	t.prefix = nil
+	t.item = nil
 	t.children = newSparseChildList(t.maxPrefixPerNode)
 }
 
@@ -382,7 +396,7 @@ func (t *Trie) put(item Item, replace bool) bool {
 		if child == nil {
 			goto AppendChild
 		}
-		node = child
+		node = t.claim(node, child)
 	}
 
 SplitPrefix:
@@ -390,6 +404,7 @@ SplitPrefix:
 	child = new(Trie)
 	*child = *node
 	*node = *NewTrie()
+	node.own = t.own
 	node.prefix = child.prefix[:common]
 	child.prefix = child.prefix[common:]
 	child = child.compact()
@@ -400,6 +415,7 @@ AppendChild:
 	// This loop starts with empty node.prefix that needs to be filled.
 	for len(key) != 0 {
 		child := NewTrie()
+		child.own = t.own
 		if len(key) <= t.maxPrefixPerNode {
 			child.prefix = key
 			node.children = node.children.add(child)
@@ -447,13 +463,11 @@ func (t *Trie) compact() *Trie {
 		return t
 	}
 
-	// Concatenate the prefixes, move the items.
-	child.prefix = append(t.prefix, child.prefix...)
-	if t.item != nil {
-		child.item = t.item
-	}
-
-	return child
+	// Concatenate the prefixes into a new node, leaving the child as it is
+	// in any fork sharing it.
+	n := *child
+	n.prefix = append(append(Prefix(nil), t.prefix...), child.prefix...)
+	return &n
 }
 
 func (t *Trie) findSubtree(prefix Prefix) (parent *Trie, root *Trie, found bool, leftover Prefix) {
@@ -557,6 +571,43 @@ func (t *Trie) walk(p Prefix, v VisitorFunc) error {
 	return t.children.walk(&prefix, v)
 }
 
+// Returns a new root sharing every other node with this Trie, any node being
+// copied as it is changed through either.
+func (t *Trie) fork() *Trie {
+	n := *t
+	n.own = new(owner)
+	n.children = t.children.shallow()
+	return &n
+}
+
+// Returns the provided node where owned by this Trie, or a copy of it that is.
+func (t *Trie) adopt(n *Trie) *Trie {
+	if n.own == t.own {
+		return n
+	}
+	c := *n
+	c.own, c.children = t.own, n.children.shallow()
+	return &c
+}
+
+// Returns the provided child of parent, owned by this Trie, replacing it
+// within parent where copied.
+func (t *Trie) claim(parent, child *Trie) *Trie {
+	c := t.adopt(child)
+	if c != child {
+		parent.children.replace(c.prefix[0], c)
+	}
+	return c
+}
+
+// Returns the provided path from this Trie with each node owned by it.
+func (t *Trie) claimPath(path []*Trie) []*Trie {
+	for n := 1; n < len(path); n++ {
+		path[n] = t.claim(path[n-1], path[n])
+	}
+	return path
+}
+
 func (t *Trie) print(writer io.Writer, indent int) {
 	fmt.Fprintf(writer, "%s%s %v\n", strings.Repeat(" ", indent), string(t.prefix), t.item)
 	t.children.print(writer, indent+2)
@@ -572,6 +623,7 @@ type childList interface {
 	walk(prefix *Prefix, visitor VisitorFunc) error
 	print(w io.Writer, indent int)
 	total() int
+	shallow() childList
 }
 
 type tries []*Trie
@@ -608,9 +660,15 @@ func (list *sparseChildList) head() *Trie {
 }
 
 func (list *sparseChildList) add(child *Trie) childList {
-	// Search for an empty spot and insert the child if possible.
+	// Search for an empty spot and insert the child in order if possible.
 	if len(list.children) != cap(list.children) {
-		list.children = append(list.children, child)
+		b := child.prefix[0]
+		i := sort.Search(len(list.children), func(i int) bool {
+			return list.children[i].prefix[0] > b
+		})
+		list.children = append(list.children, nil)
+		copy(list.children[i+1:], list.children[i:])
+		list.children[i] = child
 		return list
 	}
 
@@ -621,7 +679,7 @@ func (list *sparseChildList) add(child *Trie) childList {
 func (list *sparseChildList) remove(b byte) {
 	for i, node := range list.children {
 		if node.prefix[0] == b {
-			list.children[i] = list.children[len(list.children)-1]
+			copy(list.children[i:], list.children[i+1:])
 			list.children[len(list.children)-1] = nil
 			list.children = list.children[:len(list.children)-1]
 			return
@@ -656,9 +714,9 @@ func (list *sparseChildList) next(b byte) *Trie {
 	return nil
 }
 
+// Children are kept in order on add, so walking never modifies the list and
+// may proceed concurrently with other readers.
 func (list *sparseChildList) walk(prefix *Prefix, visitor VisitorFunc) error {
-	sort.Sort(list.children)
-
 	for _, child := range list.children {
 		*prefix = append(*prefix, child.prefix...)
 		if child.item != nil {
@@ -693,6 +751,12 @@ func (list *sparseChildList) total() int {
 	return tot
 }
 
+func (list *sparseChildList) shallow() childList {
+	children := make(tries, len(list.children), cap(list.children))
+	copy(children, list.children)
+	return &sparseChildList{children}
+}
+
 func (list *sparseChildList) print(w io.Writer, indent int) {
 	for _, child := range list.children {
 		if child != nil {
@@ -864,6 +928,13 @@ func (list *denseChildList) print(w io.Writer, indent int) {
 	}
 }
 
+func (list *denseChildList) shallow() childList {
+	n := *list
+	n.children = make([]*Trie, len(list.children))
+	copy(n.children, list.children)
+	return &n
+}
+
 func (list *denseChildList) total() int {
 	tot := 0
 	for _, child := range list.children {
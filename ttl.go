@@ -0,0 +1,80 @@
+package data
+
+import (
+	"context"
+	"time"
+)
+
+// A source of the current time, e.g. a fixed clock in tests.
+type Clock func() time.Time
+
+// Sets the Clock by which this Vector stamps and expires Item, time.Now where
+// nil. Not safe to call concurrently with other use of the Vector.
+func (v *Vector) SetClock(c Clock) {
+	v.clk = c
+}
+
+func (v *Vector) now() time.Time {
+	return v.clk.now()
+}
+
+func (v *Vector) expired(i Item) bool {
+	return v.clk.expired(i)
+}
+
+func (c Clock) now() time.Time {
+	if c != nil {
+		return c()
+	}
+	return time.Now()
+}
+
+func (c Clock) expired(i Item) bool {
+	e := i.Meta().Expires
+	return e != nil && !c.now().Before(*e)
+}
+
+// Sets the provided Item to expire after the provided duration, after which
+// Get, List, Keys, and any ToX method treat it as absent. Expired Item are
+// deleted by Expire or a janitor, see StartJanitor.
+func (v *Vector) SetWithTTL(i Item, ttl time.Duration) {
+	e, c := v.now().Add(ttl), i.Clone()
+	c.Meta().Expires = &e
+	v.Set(c)
+}
+
+// Deletes every expired Item, emitting an OpExpire Event for each, and returns
+// the number of Item deleted.
+func (v *Vector) Expire() int {
+	v.l.Lock()
+	defer v.l.Unlock()
+	var keys []string
+	v.walk(nil, func(p Prefix, i Item) error {
+		if v.expired(i) {
+			keys = append(keys, string(p))
+		}
+		return nil
+	})
+	return v.removeAs(OpExpire, keys...)
+}
+
+// Starts a janitor calling Expire at every interval until the provided
+// context is done. No janitor is started for an interval that is not
+// positive.
+func (v *Vector) StartJanitor(ctx context.Context, every time.Duration) {
+	if every <= 0 {
+		return
+	}
+	go func() {
+		t := time.NewTicker(every)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				v.Expire()
+			}
+		}
+	}()
+}
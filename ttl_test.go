@@ -0,0 +1,119 @@
+package data
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testClock struct {
+	n int64
+}
+
+func (c *testClock) now() time.Time {
+	return time.Unix(atomic.LoadInt64(&c.n), 0)
+}
+
+func (c *testClock) advance(d time.Duration) {
+	atomic.AddInt64(&c.n, int64(d/time.Second))
+}
+
+func TestTTL(t *testing.T) {
+	clk := &testClock{1000}
+	v := New("TTL")
+	v.SetClock(clk.now)
+	v.SetWithTTL(NewStringItem("a.cached", "value"), time.Minute)
+	v.SetString("a.kept", "kept")
+
+	if s := v.ToString("a.cached"); s != "value" {
+		t.Errorf("expected value before expiry, received %q", s)
+	}
+	clk.advance(time.Minute)
+	if i := v.Get("a.cached"); i != nil {
+		t.Errorf("expected expired item to be absent, received %v", i)
+	}
+	if s := v.ToString("a.cached"); s != "" {
+		t.Errorf("expected no value after expiry, received %q", s)
+	}
+	for _, i := range v.List() {
+		if i.Key() == "a.cached" {
+			t.Error("expired item listed")
+		}
+	}
+
+	c, cancel := v.Watch("a.")
+	defer cancel()
+	if n := v.Expire(); n != 1 {
+		t.Errorf("expected 1 item expired, received %d", n)
+	}
+	receive(t, c, "a.cached", OpExpire)
+	if v.ToString("a.kept") != "kept" {
+		t.Error("unexpired item removed")
+	}
+
+	v.SetWithTTL(NewStringItem("a.cached", "again"), time.Minute)
+	v.SetString("a.cached", "forever")
+	clk.advance(time.Hour)
+	if s := v.ToString("a.cached"); s != "forever" {
+		t.Errorf("set without ttl should not expire, received %q", s)
+	}
+}
+
+func TestTTLReads(t *testing.T) {
+	clk := &testClock{1000}
+	v := New("TTL")
+	v.SetClock(clk.now)
+	v.SetWithTTL(NewStringItem("a.cached", "value"), time.Minute)
+	v.SetWithTTL(NewStringsItem("a.list", "x", "y"), time.Minute)
+	s := v.Snapshot()
+	clk.advance(time.Minute)
+
+	if i := s.Get("a.cached"); i != nil {
+		t.Errorf("expected expired item absent from snapshot, received %v", i)
+	}
+	for _, k := range s.Keys() {
+		if k == "a.cached" {
+			t.Error("expired key listed by snapshot")
+		}
+	}
+	if l := len(s.List()); l != 1 {
+		t.Errorf("expected 1 item listed by snapshot, received %d", l)
+	}
+	if l := v.Match("cached"); len(l) != 0 {
+		t.Errorf("expected no expired item matched, received %v", l)
+	}
+
+	v.AppendStrings("a.list", "z")
+	if l := v.ToStrings("a.list"); len(l) != 1 || l[0] != "z" {
+		t.Errorf("expected append to an expired list to start anew, received %v", l)
+	}
+	clk.advance(time.Hour)
+	if l := v.ToStrings("a.list"); len(l) != 1 {
+		t.Errorf("appended list expired with the list it replaced: %v", l)
+	}
+}
+
+func TestJanitor(t *testing.T) {
+	clk := &testClock{1000}
+	v := New("JANITOR")
+	v.SetClock(clk.now)
+	v.SetWithTTL(NewIntItem("a.cached", 1), time.Second)
+	c, cancel := v.Watch("a.")
+	defer cancel()
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	v.StartJanitor(ctx, time.Millisecond)
+	clk.advance(time.Second)
+	select {
+	case e := <-c:
+		if e.Key != "a.cached" || e.Op != OpExpire {
+			t.Errorf("expected expire event for a.cached, received %s event for %s", e.Op, e.Key)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected expire event for a.cached, received none")
+	}
+
+	v.StartJanitor(ctx, 0)
+}
@@ -0,0 +1,418 @@
+package data
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/Laughs-In-Flowers/xrr"
+)
+
+// A set of changes to a Vector applied together on Commit or discarded on
+// Rollback. Reads through a Tx see the Tx's own pending changes. A Tx is not
+// safe for concurrent use.
+type Tx struct {
+	v     *Vector
+	ops   []txOp
+	reads map[string]mark
+	done  bool
+}
+
+type txOp struct {
+	key  string
+	item Item
+}
+
+// Begins a new Tx on this Vector.
+func (v *Vector) Begin() *Tx {
+	return &Tx{v: v}
+}
+
+// Begins a new Tx on this Vector that fails to commit if any key read through
+// the Tx has changed in the Vector since it was read.
+func (v *Vector) BeginOptimistic() *Tx {
+	return &Tx{v: v, reads: make(map[string]mark)}
+}
+
+var (
+	TxDoneError     = xrr.Xrror("transaction has already been committed or rolled back")
+	TxConflictError = xrr.Xrror("transaction conflict, key %s changed since read").Out
+)
+
+func (tx *Tx) pending(k string) (Item, bool) {
+	for n := len(tx.ops) - 1; n >= 0; n-- {
+		if op := tx.ops[n]; op.key == k {
+			return op.item, true
+		}
+	}
+	return nil, false
+}
+
+// Returns the Item at the provided key as seen by this Tx.
+func (tx *Tx) Get(k string) Item {
+	if i, ok := tx.pending(k); ok {
+		return i
+	}
+	if tx.reads != nil {
+		if _, read := tx.reads[k]; !read {
+			tx.reads[k] = markOf(tx.v.held(k)[0])
+		}
+	}
+	return tx.v.Get(k)
+}
+
+// Returns the Item held at the provided key as seen by this Tx, recorded as
+// read as by Get, with any FuncItem evaluated but any RefItem as held.
+func (tx *Tx) raw(k string) Item {
+	if i, ok := tx.pending(k); ok {
+		return i
+	}
+	i := tx.v.held(k)[0]
+	if tx.reads != nil {
+		if _, read := tx.reads[k]; !read {
+			tx.reads[k] = markOf(i)
+		}
+	}
+	return tx.v.unfollowed(i)
+}
+
+// Stages the provided Item to be set on Commit.
+func (tx *Tx) Set(i ...Item) {
+	for _, ii := range i {
+		tx.ops = append(tx.ops, txOp{ii.Key(), ii})
+	}
+}
+
+// Stages the provided keys for deletion on Commit, returning the number of
+// keys currently holding an Item as seen by this Tx.
+func (tx *Tx) Delete(keys ...string) int {
+	var n int
+	for _, k := range keys {
+		if tx.Get(k) != nil {
+			n++
+		}
+		tx.ops = append(tx.ops, txOp{k, nil})
+	}
+	return n
+}
+
+// Applies all staged changes to the Vector under a single lock.
+func (tx *Tx) Commit() error {
+	if tx.done {
+		return TxDoneError
+	}
+	tx.done = true
+	v := tx.v
+	v.l.Lock()
+	defer v.l.Unlock()
+	if v.frozen {
+		return ErrFrozen
+	}
+	for k, m := range tx.reads {
+		if markOf(v.get(Prefix(k))) != m {
+			return TxConflictError(k)
+		}
+	}
+	for _, op := range tx.ops {
+		if op.item == nil {
+			v.remove(op.key)
+			continue
+		}
+		v.setItems(OpSet, op.item)
+	}
+	return nil
+}
+
+// The Item held at a key as read, by its Meta and revision, telling whether the
+// key has changed since without comparing Item, which may not be comparable.
+type mark struct {
+	m   *Meta
+	rev uint64
+}
+
+func markOf(i Item) mark {
+	if i == nil {
+		return mark{}
+	}
+	m := i.Meta()
+	return mark{m, m.Revision}
+}
+
+// Discards all staged changes.
+func (tx *Tx) Rollback() error {
+	if tx.done {
+		return TxDoneError
+	}
+	tx.done = true
+	tx.ops = nil
+	return nil
+}
+
+// Return a string from key matching a stored StringItem.
+func (tx *Tx) ToString(k string) string {
+	return toString(tx.Get(k))
+}
+
+// Stage a StringItem with the provided key and value.
+func (tx *Tx) SetString(k, vi string) {
+	tx.Set(NewStringItem(k, vi))
+}
+
+// Return an array of strings from a matching key.
+func (tx *Tx) ToStrings(k string) []string {
+	return toStrings(tx.Get(k))
+}
+
+// Stage a StringsItem with the provided key and string values.
+func (tx *Tx) SetStrings(k string, vi ...string) {
+	tx.Set(NewStringsItem(k, vi...))
+}
+
+// Return a boolean from a matching key.
+func (tx *Tx) ToBool(k string) bool {
+	return toBool(tx.Get(k))
+}
+
+// Stage a BoolItem with the provided key and boolean value.
+func (tx *Tx) SetBool(k string, vi bool) {
+	tx.Set(NewBoolItem(k, vi))
+}
+
+// Return an integer from a matching key.
+func (tx *Tx) ToInt(k string) int {
+	return toInt(tx.Get(k))
+}
+
+// Stage an IntItem with the provided key and integer value.
+func (tx *Tx) SetInt(k string, vi int) {
+	tx.Set(NewIntItem(k, vi))
+}
+
+// Return an int64 from a matching key.
+func (tx *Tx) ToInt64(k string) int64 {
+	return toInt64(tx.Get(k))
+}
+
+// Stage an Int64Item with the provided key and int64 value.
+func (tx *Tx) SetInt64(k string, vi int64) {
+	tx.Set(NewInt64Item(k, vi))
+}
+
+// Return an uint from a matching key.
+func (tx *Tx) ToUint(k string) uint {
+	return toUint(tx.Get(k))
+}
+
+// Stage an UintItem with the provided key and uint value.
+func (tx *Tx) SetUint(k string, vi uint) {
+	tx.Set(NewUintItem(k, vi))
+}
+
+// Return an uint64 from a matching key.
+func (tx *Tx) ToUint64(k string) uint64 {
+	return toUint64(tx.Get(k))
+}
+
+// Stage an Uint64Item with the provided key and uint64 value.
+func (tx *Tx) SetUint64(k string, vi uint64) {
+	tx.Set(NewUint64Item(k, vi))
+}
+
+// Return a float64 from a matching key.
+func (tx *Tx) ToFloat64(k string) float64 {
+	return toFloat64(tx.Get(k))
+}
+
+// Stage a Float64Item with the provided key and float64 value.
+func (tx *Tx) SetFloat64(k string, vi float64) {
+	tx.Set(NewFloat64Item(k, vi))
+}
+
+// Return a time.Time from a matching key.
+func (tx *Tx) ToTime(k string) time.Time {
+	return toTime(tx.Get(k))
+}
+
+// Stage a TimeItem with the provided key and time.Time value.
+func (tx *Tx) SetTime(k string, vi time.Time) {
+	tx.Set(NewTimeItem(k, vi))
+}
+
+// Return a time.Duration from a matching key.
+func (tx *Tx) ToDuration(k string) time.Duration {
+	return toDuration(tx.Get(k))
+}
+
+// Stage a DurationItem with the provided key and time.Duration value.
+func (tx *Tx) SetDuration(k string, vi time.Duration) {
+	tx.Set(NewDurationItem(k, vi))
+}
+
+// Return a *Vector from a key matching a stored VectorItem.
+func (tx *Tx) ToVector(k string) *Vector {
+	return toVector(tx.Get(k))
+}
+
+// Stage a VectorItem with the provided key and *Vector value.
+func (tx *Tx) SetVector(k string, vi *Vector) {
+	tx.Set(NewVectorItem(k, vi))
+}
+
+// Return a map from a key matching a stored MapItem.
+func (tx *Tx) ToMap(k string) map[string]interface{} {
+	return toMap(tx.Get(k))
+}
+
+// Stage a MapItem with the provided key and map value.
+func (tx *Tx) SetMap(k string, vi map[string]interface{}) {
+	tx.Set(NewMapItem(k, vi))
+}
+
+// Return a []byte from a key matching a stored BytesItem.
+func (tx *Tx) ToBytes(k string) []byte {
+	return toBytes(tx.Get(k))
+}
+
+// Stage a BytesItem with the provided key and []byte value.
+func (tx *Tx) SetBytes(k string, vi []byte) {
+	tx.Set(NewBytesItem(k, vi))
+}
+
+// Return an int8 from a key matching a stored Int8Item.
+func (tx *Tx) ToInt8(k string) int8 {
+	return toInt8(tx.Get(k))
+}
+
+// Stage an Int8Item with the provided key and int8 value.
+func (tx *Tx) SetInt8(k string, vi int8) {
+	tx.Set(NewInt8Item(k, vi))
+}
+
+// Return an int16 from a key matching a stored Int16Item.
+func (tx *Tx) ToInt16(k string) int16 {
+	return toInt16(tx.Get(k))
+}
+
+// Stage an Int16Item with the provided key and int16 value.
+func (tx *Tx) SetInt16(k string, vi int16) {
+	tx.Set(NewInt16Item(k, vi))
+}
+
+// Return an int32 from a key matching a stored Int32Item.
+func (tx *Tx) ToInt32(k string) int32 {
+	return toInt32(tx.Get(k))
+}
+
+// Stage an Int32Item with the provided key and int32 value.
+func (tx *Tx) SetInt32(k string, vi int32) {
+	tx.Set(NewInt32Item(k, vi))
+}
+
+// Return an uint8 from a key matching a stored Uint8Item.
+func (tx *Tx) ToUint8(k string) uint8 {
+	return toUint8(tx.Get(k))
+}
+
+// Stage an Uint8Item with the provided key and uint8 value.
+func (tx *Tx) SetUint8(k string, vi uint8) {
+	tx.Set(NewUint8Item(k, vi))
+}
+
+// Return an uint16 from a key matching a stored Uint16Item.
+func (tx *Tx) ToUint16(k string) uint16 {
+	return toUint16(tx.Get(k))
+}
+
+// Stage an Uint16Item with the provided key and uint16 value.
+func (tx *Tx) SetUint16(k string, vi uint16) {
+	tx.Set(NewUint16Item(k, vi))
+}
+
+// Return an uint32 from a key matching a stored Uint32Item.
+func (tx *Tx) ToUint32(k string) uint32 {
+	return toUint32(tx.Get(k))
+}
+
+// Stage an Uint32Item with the provided key and uint32 value.
+func (tx *Tx) SetUint32(k string, vi uint32) {
+	tx.Set(NewUint32Item(k, vi))
+}
+
+// Return a float32 from a key matching a stored Float32Item.
+func (tx *Tx) ToFloat32(k string) float32 {
+	return toFloat32(tx.Get(k))
+}
+
+// Stage a Float32Item with the provided key and float32 value.
+func (tx *Tx) SetFloat32(k string, vi float32) {
+	tx.Set(NewFloat32Item(k, vi))
+}
+
+// Return a *big.Int from a key matching a stored BigIntItem.
+func (tx *Tx) ToBigInt(k string) *big.Int {
+	return toBigInt(tx.Get(k))
+}
+
+// Stage a BigIntItem with the provided key and *big.Int value.
+func (tx *Tx) SetBigInt(k string, vi *big.Int) {
+	tx.Set(NewBigIntItem(k, vi))
+}
+
+// Return a *big.Float from a key matching a stored BigFloatItem.
+func (tx *Tx) ToBigFloat(k string) *big.Float {
+	return toBigFloat(tx.Get(k))
+}
+
+// Stage a BigFloatItem with the provided key and *big.Float value.
+func (tx *Tx) SetBigFloat(k string, vi *big.Float) {
+	tx.Set(NewBigFloatItem(k, vi))
+}
+
+// Return a *big.Rat from a key matching a stored DecimalItem.
+func (tx *Tx) ToDecimal(k string) *big.Rat {
+	return toDecimal(tx.Get(k))
+}
+
+// Stage a DecimalItem with the provided key and *big.Rat value.
+func (tx *Tx) SetDecimal(k string, vi *big.Rat) {
+	tx.Set(NewDecimalItem(k, vi))
+}
+
+// Return an array of integers from a key matching a stored IntsItem.
+func (tx *Tx) ToInts(k string) []int {
+	return toInts(tx.Get(k))
+}
+
+// Stage an IntsItem with the provided key and int values.
+func (tx *Tx) SetInts(k string, vi ...int) {
+	tx.Set(NewIntsItem(k, vi...))
+}
+
+// Return an array of float64 from a key matching a stored Float64sItem.
+func (tx *Tx) ToFloat64s(k string) []float64 {
+	return toFloat64s(tx.Get(k))
+}
+
+// Stage a Float64sItem with the provided key and float64 values.
+func (tx *Tx) SetFloat64s(k string, vi ...float64) {
+	tx.Set(NewFloat64sItem(k, vi...))
+}
+
+// Return an array of booleans from a key matching a stored BoolsItem.
+func (tx *Tx) ToBools(k string) []bool {
+	return toBools(tx.Get(k))
+}
+
+// Stage a BoolsItem with the provided key and bool values.
+func (tx *Tx) SetBools(k string, vi ...bool) {
+	tx.Set(NewBoolsItem(k, vi...))
+}
+
+// Return an array of any values from a key matching a stored ListItem.
+func (tx *Tx) ToList(k string) []interface{} {
+	return toList(tx.Get(k))
+}
+
+// Stage a ListItem with the provided key and interface{} values.
+func (tx *Tx) SetList(k string, vi ...interface{}) {
+	tx.Set(NewListItem(k, vi...))
+}
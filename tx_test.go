@@ -0,0 +1,64 @@
+package data
+
+import "testing"
+
+func TestTx(t *testing.T) {
+	v := New("TX")
+	v.SetInt("a.int", 1)
+	v.SetString("a.string", "one")
+
+	tx := v.Begin()
+	tx.SetInt("a.int", 2)
+	tx.SetBool("a.bool", true)
+	if n := tx.Delete("a.string"); n != 1 {
+		t.Errorf("expected 1 key staged for deletion, staged %d", n)
+	}
+	if i := tx.ToInt("a.int"); i != 2 {
+		t.Errorf("transaction does not read its own write: %d", i)
+	}
+	if i := v.ToInt("a.int"); i != 1 {
+		t.Errorf("uncommitted write visible in vector: %d", i)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Error(err)
+	}
+	if i, b, s := v.ToInt("a.int"), v.ToBool("a.bool"), v.Get("a.string"); i != 2 || !b || s != nil {
+		t.Errorf("committed values incorrect: %d, %t, %v", i, b, s)
+	}
+	if err := tx.Commit(); err != TxDoneError {
+		t.Errorf("expected TxDoneError, received %v", err)
+	}
+
+	tx = v.Begin()
+	tx.SetInt("a.int", 3)
+	if err := tx.Rollback(); err != nil {
+		t.Error(err)
+	}
+	if i := v.ToInt("a.int"); i != 2 {
+		t.Errorf("rolled back write visible in vector: %d", i)
+	}
+}
+
+func TestTxOptimistic(t *testing.T) {
+	v := New("TX")
+	v.SetInt("a.int", 1)
+
+	tx := v.BeginOptimistic()
+	tx.SetInt("a.int", tx.ToInt("a.int")+1)
+	v.SetInt("a.int", 10)
+	if err := tx.Commit(); err == nil {
+		t.Error("expected conflict error committing transaction")
+	}
+	if i := v.ToInt("a.int"); i != 10 {
+		t.Errorf("conflicting transaction was applied: %d", i)
+	}
+
+	tx = v.BeginOptimistic()
+	tx.SetInt("a.int", tx.ToInt("a.int")+1)
+	if err := tx.Commit(); err != nil {
+		t.Error(err)
+	}
+	if i := v.ToInt("a.int"); i != 11 {
+		t.Errorf("expected 11, received %d", i)
+	}
+}
@@ -1,27 +1,45 @@
 package data
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
-	"strconv"
+	"io"
+	"math/big"
+	"reflect"
 	"sync"
+	"time"
 )
 
 // A sync.Mutex bound struct that wraps a Trie holding package level Item.
 type Vector struct {
-	l  *sync.RWMutex
-	o  []Option
-	bl []string
-	*Trie
+	l   *sync.RWMutex
+	o   []Option
+	bl  []string
+	w   *watchers
+	ver uint64
+	cow bool
+	tm  bool
+	clk Clock
+	ev  *evaluation
+	*trie
+	frozen bool
 }
 
+// The Trie held by a Vector, embedded unexported so that it is read and changed
+// only through the Vector, under lock.
+type trie = Trie
+
 //
 func New(tag string, o ...Option) *Vector {
 	t := NewTrie(o...)
 	v := &Vector{
-		nil, o, make([]string, 0), t,
+		o:    o,
+		bl:   make([]string, 0),
+		trie: t,
 	}
 	v.mutexSet()
+	v.watchSet()
 	v.Set(NewStringItem("vector.tag", tag))
 	return v
 }
@@ -33,8 +51,8 @@ func (v *Vector) mutexSet() {
 }
 
 func (v *Vector) trieSet() {
-	if v.Trie == nil {
-		v.Trie = NewTrie(v.o...)
+	if v.trie == nil {
+		v.trie = NewTrie(v.o...)
 	}
 }
 
@@ -43,6 +61,7 @@ func (v *Vector) trieSet() {
 func (v *Vector) ensureNotEmpty() {
 	v.mutexSet()
 	v.trieSet()
+	v.watchSet()
 }
 
 //
@@ -52,9 +71,8 @@ func (v *Vector) Tag() string {
 
 //
 func (v *Vector) Retag(t string) {
-	i := v.Get("vector.tag")
-	if i != nil {
-		i.Provide(t)
+	if i := v.Get("vector.tag"); i != nil {
+		v.Set(NewStringItem("vector.tag", t))
 	}
 }
 
@@ -62,31 +80,62 @@ func (v *Vector) Retag(t string) {
 func (v *Vector) Keys() []string {
 	var ret []string
 	w := func(p Prefix, i Item) error {
-		ret = append(ret, string(p))
+		if !v.expired(i) {
+			ret = append(ret, string(p))
+		}
 		return nil
 	}
 	v.walk(nil, w)
 	return ret
 }
 
-//
+// Returns the Item held at the provided key, or for an indexed key, e.g.
+// "hosts[2]", the element of the list Item held at "hosts" as an Item. A
+// FuncItem is returned as the Item it evaluates to, or nil where it fails; see
+// Eval.
 func (v *Vector) Get(k string) Item {
+	i, _ := v.Eval(k)
+	return i
+}
+
+// Returns the Item at the provided key as Get, or the error of a FuncItem
+// failing to evaluate, or of a RefItem failing to resolve.
+func (v *Vector) Eval(k string) (Item, error) {
+	return v.eval(k, nil)
+}
+
+// Reads the Item at the provided key as Eval, refs being the keys of any
+// RefItem followed to it.
+func (v *Vector) eval(k string, refs []string) (Item, error) {
 	v.l.RLock()
 	key := Prefix(k)
-	i := v.get(key)
+	i := v.out(v.get(key))
 	v.l.RUnlock()
-	return i
+	if i == nil {
+		return indexed(k, v.Get), nil
+	}
+	if v.expired(i) {
+		return nil, nil
+	}
+	switch ii := i.(type) {
+	case FuncItem:
+		return ii.Eval(v)
+	case RefItem:
+		return v.deref(k, ii.Ref(), refs)
+	}
+	return i, nil
 }
 
-//
+// Returns every Item with a key containing the provided string. See Prefix,
+// Glob and Regexp for narrower queries.
 func (v *Vector) Match(k string) []Item {
 	v.l.RLock()
 	defer v.l.RUnlock()
 	var ret []Item
 	bk := []byte(k)
 	w := func(p Prefix, i Item) error {
-		if bytes.Contains(p, bk) {
-			ret = append(ret, i)
+		if bytes.Contains(p, bk) && !v.expired(i) {
+			ret = append(ret, v.out(i))
 		}
 		return nil
 	}
@@ -94,6 +143,51 @@ func (v *Vector) Match(k string) []Item {
 	return ret
 }
 
+// Returns the Item held at the root of this Vector's Trie, as Trie.Item.
+func (v *Vector) Item() Item {
+	v.l.RLock()
+	defer v.l.RUnlock()
+	if i := v.trie.Item(); i != nil && !v.expired(i) {
+		return v.out(i)
+	}
+	return nil
+}
+
+// Visits every Item held by this Vector as Trie.Visit. The function is called
+// under lock and must not change the Vector; see Match and List.
+func (v *Vector) Visit(fn VisitorFunc) error {
+	v.l.RLock()
+	defer v.l.RUnlock()
+	return v.trie.Visit(v.visitor(fn))
+}
+
+// Visits every Item with a key beginning with the provided prefix as
+// Trie.VisitSubtree, called as Visit.
+func (v *Vector) VisitSubtree(p Prefix, fn VisitorFunc) error {
+	v.l.RLock()
+	defer v.l.RUnlock()
+	return v.trie.VisitSubtree(p, v.visitor(fn))
+}
+
+// Visits every Item with a key that is a prefix of the provided key as
+// Trie.VisitPrefixes, called as Visit.
+func (v *Vector) VisitPrefixes(p Prefix, fn VisitorFunc) error {
+	v.l.RLock()
+	defer v.l.RUnlock()
+	return v.trie.VisitPrefixes(p, v.visitor(fn))
+}
+
+// Returns the provided VisitorFunc skipping expired Item and reading a copy of
+// any Item held by a frozen Vector.
+func (v *Vector) visitor(fn VisitorFunc) VisitorFunc {
+	return func(p Prefix, i Item) error {
+		if v.expired(i) {
+			return nil
+		}
+		return fn(p, v.out(i))
+	}
+}
+
 func (v *Vector) Blacklist(keys ...string) {
 	v.bl = append(v.bl, keys...)
 }
@@ -119,20 +213,35 @@ func notBlacklisted(bl []string, i []Item) []Item {
 
 //
 func (v *Vector) Set(i ...Item) {
-	nbi := notBlacklisted(v.bl, i)
+	v.setAs(OpSet, i...)
+}
+
+func (v *Vector) setAs(o Op, i ...Item) {
 	v.l.Lock()
-	v.set(nbi...)
+	v.setItems(o, i...)
 	v.l.Unlock()
 }
 
-//
-func (v *Vector) Merge(vs ...*Vector) {
-	for _, vv := range vs {
-		l := vv.List("vector.tag", "vector.id")
-		v.Set(l...)
+func (v *Vector) setItems(o Op, i ...Item) {
+	if v.frozen {
+		return
+	}
+	for _, ii := range notBlacklisted(v.bl, i) {
+		old := v.get(Prefix(ii.Key()))
+		v.stamp(old, ii)
+		v.touch()
+		v.put(ii, true)
+		v.emit(o, ii.Key(), old, ii)
 	}
 }
 
+// Merges the provided Vector into this Vector, in order, overwriting any Item
+// already held. See MergeWith.
+func (v *Vector) Merge(vs ...*Vector) *MergeReport {
+	r, _ := v.MergeWith(MergeOverwrite, vs...)
+	return r
+}
+
 //
 func (v *Vector) Clone(except ...string) *Vector {
 	except = append(except, "vector.tag")
@@ -168,8 +277,8 @@ func (v *Vector) List(except ...string) []Item {
 	defer v.l.RUnlock()
 	var ret []Item
 	w := func(p Prefix, i Item) error {
-		if !match(except, i.Key()) {
-			ret = append(ret, i)
+		if !match(except, i.Key()) && !v.expired(i) {
+			ret = append(ret, v.out(i))
 		}
 		return nil
 	}
@@ -177,26 +286,124 @@ func (v *Vector) List(except ...string) []Item {
 	return ret
 }
 
+// Deletes the Item at each provided key, returning the number of Item removed.
+// Blacklisted keys are left in place.
+func (v *Vector) Delete(keys ...string) int {
+	v.l.Lock()
+	defer v.l.Unlock()
+	return v.remove(keys...)
+}
+
+// Deletes every Item with a key beginning with the provided prefix, returning
+// the number of Item removed. Blacklisted keys and "vector.tag" are left in
+// place.
+func (v *Vector) DeleteSubtree(p string) int {
+	v.l.Lock()
+	defer v.l.Unlock()
+	var keys []string
+	w := func(_ Prefix, i Item) error {
+		if k := i.Key(); k != "vector.tag" {
+			keys = append(keys, k)
+		}
+		return nil
+	}
+	v.trie.VisitSubtree(Prefix(p), w)
+	return v.remove(keys...)
+}
+
+// Deletes every Item for which the provided function returns true, returning
+// the number of Item removed. Blacklisted keys and "vector.tag" are left in
+// place.
+func (v *Vector) DeleteFunc(fn func(Item) bool) int {
+	v.l.Lock()
+	defer v.l.Unlock()
+	var keys []string
+	w := func(_ Prefix, i Item) error {
+		if k := i.Key(); k != "vector.tag" && fn(i) {
+			keys = append(keys, k)
+		}
+		return nil
+	}
+	v.walk(nil, w)
+	return v.remove(keys...)
+}
+
+func (v *Vector) remove(keys ...string) int {
+	return v.removeAs(OpDelete, keys...)
+}
+
+func (v *Vector) removeAs(o Op, keys ...string) int {
+	if v.frozen {
+		return 0
+	}
+	var n int
+	for _, k := range keys {
+		if inList(k, v.bl) {
+			continue
+		}
+		p := Prefix(k)
+		if old := v.get(p); old != nil {
+			v.touch()
+			v.trie.Delete(p)
+			v.emit(o, k, old, nil)
+			n++
+		}
+	}
+	return n
+}
+
 // Clears the Vector of all Item.
 func (v *Vector) Clear() {
-	v.reset()
+	v.clear(nil)
 }
 
 // Clears the Vector of all Item, except those matching the internal "vector"
 // key e.g. "vector.tag", "vector.id", etc et al.
 func (v *Vector) Reset() {
-	ci := v.Match("vector")
-	v.reset()
-	v.Set(ci...)
+	v.clear(v.Match("vector"))
+}
+
+func (v *Vector) clear(keep []Item) error {
+	v.l.Lock()
+	defer v.l.Unlock()
+	if v.frozen {
+		return ErrFrozen
+	}
+	var old []Item
+	v.walk(nil, func(_ Prefix, i Item) error {
+		old = append(old, i)
+		return nil
+	})
+	if v.cow {
+		v.trie, v.cow = NewTrie(v.o...), false
+	} else {
+		v.reset()
+	}
+	v.ver++
+	v.set(keep...)
+	kept := keyList(keep)
+	for _, i := range old {
+		if !inList(i.Key(), kept) {
+			v.emit(OpReset, i.Key(), i, nil)
+		}
+	}
+	return nil
+}
+
+func keyList(i []Item) []string {
+	var ret []string
+	for _, ii := range i {
+		ret = append(ret, ii.Key())
+	}
+	return ret
 }
 
 // Returns the Vector data as a map[string]interface{} suitable for use with
 // text.Template or html.Template. Keys are undotted form(e.g. key.key becomes
-// KeyKey).
+// KeyKey). Any key holding a FuncItem that fails to evaluate is omitted.
 func (v *Vector) TemplateData() map[string]interface{} {
 	ret := make(map[string]interface{})
-	l := v.List()
-	for _, i := range l {
+	for _, i := range v.readList() {
 		ret[i.KeyUndotted()] = i.Provided()
 	}
 	return ret
@@ -204,29 +411,67 @@ func (v *Vector) TemplateData() map[string]interface{} {
 
 // json.Marshaler
 func (v *Vector) MarshalJSON() ([]byte, error) {
-	l := v.List()
+	l, err := v.transmitted()
+	if err != nil {
+		return nil, err
+	}
 	return json.Marshal(&l)
 }
 
+// Writes the Vector to w as json, as MarshalJSON, streaming the value of any
+// BytesItem rather than holding it in memory.
+func (v *Vector) EncodeJSON(w io.Writer) error {
+	l, err := v.transmitted()
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	bw.WriteString("[")
+	for n, i := range l {
+		if n > 0 {
+			bw.WriteString(",")
+		}
+		if e, ok := i.(interface{ encodeJSON(io.Writer) error }); ok {
+			if err := e.encodeJSON(bw); err != nil {
+				return err
+			}
+			continue
+		}
+		b, err := json.Marshal(i)
+		if err != nil {
+			return err
+		}
+		bw.Write(b)
+	}
+	bw.WriteString("]")
+	return bw.Flush()
+}
+
 // json.Unmarshaler
 func (v *Vector) UnmarshalJSON(b []byte) error {
 	v.ensureNotEmpty()
 	var i []*Mtem
-	err := json.Unmarshal(b, &i)
+	err := decodeJSON(b, &i)
 	if err != nil {
 		return err
 	}
 	var ii []Item
-	for _, v := range i {
-		ii = append(ii, fromMtem(v))
+	for _, m := range i {
+		mi, err := fromMtem(m)
+		if err != nil {
+			return err
+		}
+		if m.Meta != nil {
+			*mi.Meta() = *m.Meta
+		}
+		ii = append(ii, mi)
 	}
-	v.Set(ii...)
-	return nil
+	return v.TrySet(ii...)
 }
 
 // yaml.Marshaler
 func (v *Vector) MarshalYAML() (interface{}, error) {
-	return v.List(), nil
+	return v.transmitted()
 }
 
 // yaml.Unmarshaler
@@ -238,21 +483,27 @@ func (v *Vector) UnmarshalYAML(u func(interface{}) error) error {
 		return err
 	}
 	var ii []Item
-	for _, v := range i {
-		ii = append(ii, fromMtem(v))
+	for _, m := range i {
+		mi, err := fromMtem(m)
+		if err != nil {
+			return err
+		}
+		if m.Meta != nil {
+			*mi.Meta() = *m.Meta
+		}
+		ii = append(ii, mi)
 	}
-	v.Set(ii...)
-	return nil
+	return v.TrySet(ii...)
 }
 
 // Return a string from key matching a stored StringItem.
 func (v *Vector) ToString(k string) string {
-	if i := v.Get(k); i != nil {
-		if ii, ok := i.(StringItem); ok {
-			return ii.ToString()
-		}
-	}
-	return ""
+	return toString(v.Get(k))
+}
+
+func toString(i Item) string {
+	r, _ := getString("", i)
+	return r
 }
 
 // Set a StringItem with the provided key and value.
@@ -265,13 +516,15 @@ func (v *Vector) SetString(k, vi string) {
 // Storing a StringsItem is relatively faster, but will attempt to return strings
 // from a StringItem.
 func (v *Vector) ToStrings(k string) []string {
-	if i := v.Get(k); i != nil {
-		if ii, ok := i.(StringsItem); ok {
-			return ii.ToStrings()
-		}
-		//
+	return toStrings(v.Get(k))
+}
+
+func toStrings(i Item) []string {
+	r, err := getStrings("", i)
+	if err != nil {
+		return []string{}
 	}
-	return []string{}
+	return r
 }
 
 // Set a StringsItem with the provided key and string values.
@@ -284,18 +537,12 @@ func (v *Vector) SetStrings(k string, vi ...string) {
 // Storing a BoolItem is relatively faster, but will attempt to return a bool
 // from a StringItem.
 func (v *Vector) ToBool(k string) bool {
-	if i := v.Get(k); i != nil {
-		if ii, ok := i.(BoolItem); ok {
-			return ii.ToBool()
-		}
-		if iis, ok := i.(StringItem); ok {
-			v := iis.ToString()
-			if b, err := strconv.ParseBool(v); err == nil {
-				return b
-			}
-		}
-	}
-	return false
+	return toBool(v.Get(k))
+}
+
+func toBool(i Item) bool {
+	r, _ := getBool("", i)
+	return r
 }
 
 // Set a BoolItem with the provided key and boolean value.
@@ -308,18 +555,12 @@ func (v *Vector) SetBool(k string, vi bool) {
 // Storing an IntItem is relatively faster, but will attempt to return an int
 // from a StringItem.
 func (v *Vector) ToInt(k string) int {
-	if i := v.Get(k); i != nil {
-		if ii, ok := i.(IntItem); ok {
-			return ii.ToInt()
-		}
-		if iii, ok := i.(StringItem); ok {
-			v := iii.ToString()
-			if ri, err := strconv.ParseInt(v, 10, 64); err == nil {
-				return int(ri)
-			}
-		}
-	}
-	return 0
+	return toInt(v.Get(k))
+}
+
+func toInt(i Item) int {
+	r, _ := getInt("", i)
+	return r
 }
 
 // Set an IntItem with the provided key and integer value.
@@ -332,18 +573,12 @@ func (v *Vector) SetInt(k string, vi int) {
 // Storing an Int64Item is relatively faster, but will attempt to return an int64
 // from a StringItem.
 func (v *Vector) ToInt64(k string) int64 {
-	if i := v.Get(k); i != nil {
-		if ii, ok := i.(Int64Item); ok {
-			return ii.ToInt64()
-		}
-		if iii, ok := i.(StringItem); ok {
-			v := iii.ToString()
-			if r, err := strconv.ParseInt(v, 10, 64); err == nil {
-				return r
-			}
-		}
-	}
-	return 0
+	return toInt64(v.Get(k))
+}
+
+func toInt64(i Item) int64 {
+	r, _ := getInt64("", i)
+	return r
 }
 
 // Set an Int64Item with the provided key and int64 value.
@@ -356,18 +591,12 @@ func (v *Vector) SetInt64(k string, vi int64) {
 // Storing a UintItem is relatively faster, but will attempt to return a uint
 // from a StringItem.
 func (v *Vector) ToUint(k string) uint {
-	if i := v.Get(k); i != nil {
-		if ii, ok := i.(UintItem); ok {
-			return ii.ToUint()
-		}
-		if iii, ok := i.(StringItem); ok {
-			v := iii.ToString()
-			if r, err := strconv.ParseUint(v, 10, 64); err == nil {
-				return uint(r)
-			}
-		}
-	}
-	return 0
+	return toUint(v.Get(k))
+}
+
+func toUint(i Item) uint {
+	r, _ := getUint("", i)
+	return r
 }
 
 // Set an UintItem with the provided key and uint value.
@@ -380,18 +609,12 @@ func (v *Vector) SetUint(k string, vi uint) {
 // Storing a Uint64Item is relatively faster, but will attempt to return a uint64
 // from a StringItem.
 func (v *Vector) ToUint64(k string) uint64 {
-	if i := v.Get(k); i != nil {
-		if ii, ok := i.(Uint64Item); ok {
-			return ii.ToUint64()
-		}
-		if iii, ok := i.(StringItem); ok {
-			v := iii.ToString()
-			if r, err := strconv.ParseUint(v, 10, 64); err == nil {
-				return r
-			}
-		}
-	}
-	return 0
+	return toUint64(v.Get(k))
+}
+
+func toUint64(i Item) uint64 {
+	r, _ := getUint64("", i)
+	return r
 }
 
 // Set an Uint64Item with the provided key and uint64 value.
@@ -404,18 +627,12 @@ func (v *Vector) SetUint64(k string, vi uint64) {
 // Storing a float64Item is relatively faster, but will attempt to return a float64
 // from a StringItem.
 func (v *Vector) ToFloat64(k string) float64 {
-	if i := v.Get(k); i != nil {
-		if ii, ok := i.(Float64Item); ok {
-			return ii.ToFloat64()
-		}
-		if iii, ok := i.(StringItem); ok {
-			v := iii.ToString()
-			if rf, err := strconv.ParseFloat(v, 64); err == nil {
-				return rf
-			}
-		}
-	}
-	return 0
+	return toFloat64(v.Get(k))
+}
+
+func toFloat64(i Item) float64 {
+	r, _ := getFloat64("", i)
+	return r
 }
 
 /// Set a Float64Item with the provided key and float64 value.
@@ -424,12 +641,65 @@ func (v *Vector) SetFloat64(k string, vi float64) {
 	v.Set(ni)
 }
 
+// Return a time.Time from a matching key.
+// Storing a TimeItem is relatively faster, but will attempt to return a
+// time.Time from an RFC 3339 StringItem.
+func (v *Vector) ToTime(k string) time.Time {
+	return toTime(v.Get(k))
+}
+
+func toTime(i Item) time.Time {
+	r, _ := getTime("", i)
+	return r
+}
+
+// Set a TimeItem with the provided key and time.Time value.
+func (v *Vector) SetTime(k string, vi time.Time) {
+	ni := NewTimeItem(k, vi)
+	v.Set(ni)
+}
+
+// Return a time.Duration from a matching key.
+// Storing a DurationItem is relatively faster, but will attempt to return a
+// time.Duration from a StringItem, e.g. "1m30s".
+func (v *Vector) ToDuration(k string) time.Duration {
+	return toDuration(v.Get(k))
+}
+
+func toDuration(i Item) time.Duration {
+	r, _ := getDuration("", i)
+	return r
+}
+
+// Set a DurationItem with the provided key and time.Duration value.
+func (v *Vector) SetDuration(k string, vi time.Duration) {
+	ni := NewDurationItem(k, vi)
+	v.Set(ni)
+}
+
 // Return a *Vector from a key matching a stored VectorItem.
 func (v *Vector) ToVector(k string) *Vector {
-	if i := v.Get(k); i != nil {
-		if ii, ok := i.(VectorItem); ok {
-			return ii.ToVector()
-		}
+	return toVector(v.Get(k))
+}
+
+func toVector(i Item) *Vector {
+	r, _ := getVector("", i)
+	return r
+}
+
+// Returns the Vector held by any VectorItem, to be read but not changed.
+func heldVector(i Item) *Vector {
+	if ii, ok := i.(VectorItem); ok {
+		return ii.Get()
+	}
+	return nil
+}
+
+// Returns a copy of the Vector held by any VectorItem, to be changed and set
+// again without changing the Item.
+func copyVector(i Item) *Vector {
+	if r := heldVector(i); r != nil {
+		return r.Snapshot().Vector()
 	}
 	return nil
 }
@@ -439,3 +709,320 @@ func (v *Vector) SetVector(k string, vi *Vector) {
 	ni := NewVectorItem(k, vi)
 	v.Set(ni)
 }
+
+// Return a map from a key matching a stored MapItem.
+func (v *Vector) ToMap(k string) map[string]interface{} {
+	return toMap(v.Get(k))
+}
+
+func toMap(i Item) map[string]interface{} {
+	r, _ := getMap("", i)
+	return r
+}
+
+// Set a MapItem with the provided key and map value.
+func (v *Vector) SetMap(k string, vi map[string]interface{}) {
+	ni := NewMapItem(k, vi)
+	v.Set(ni)
+}
+
+// Return a []byte from a key matching a stored BytesItem.
+func (v *Vector) ToBytes(k string) []byte {
+	return toBytes(v.Get(k))
+}
+
+func toBytes(i Item) []byte {
+	r, _ := getBytes("", i)
+	return r
+}
+
+// Set a BytesItem with the provided key and []byte value.
+func (v *Vector) SetBytes(k string, vi []byte) {
+	ni := NewBytesItem(k, vi)
+	v.Set(ni)
+}
+
+// Return an int8 from a key matching a stored Int8Item.
+func (v *Vector) ToInt8(k string) int8 {
+	return toInt8(v.Get(k))
+}
+
+func toInt8(i Item) int8 {
+	r, _ := getInt8("", i)
+	return r
+}
+
+// Set an Int8Item with the provided key and int8 value.
+func (v *Vector) SetInt8(k string, vi int8) {
+	ni := NewInt8Item(k, vi)
+	v.Set(ni)
+}
+
+// Return an int16 from a key matching a stored Int16Item.
+func (v *Vector) ToInt16(k string) int16 {
+	return toInt16(v.Get(k))
+}
+
+func toInt16(i Item) int16 {
+	r, _ := getInt16("", i)
+	return r
+}
+
+// Set an Int16Item with the provided key and int16 value.
+func (v *Vector) SetInt16(k string, vi int16) {
+	ni := NewInt16Item(k, vi)
+	v.Set(ni)
+}
+
+// Return an int32 from a key matching a stored Int32Item.
+func (v *Vector) ToInt32(k string) int32 {
+	return toInt32(v.Get(k))
+}
+
+func toInt32(i Item) int32 {
+	r, _ := getInt32("", i)
+	return r
+}
+
+// Set an Int32Item with the provided key and int32 value.
+func (v *Vector) SetInt32(k string, vi int32) {
+	ni := NewInt32Item(k, vi)
+	v.Set(ni)
+}
+
+// Return an uint8 from a key matching a stored Uint8Item.
+func (v *Vector) ToUint8(k string) uint8 {
+	return toUint8(v.Get(k))
+}
+
+func toUint8(i Item) uint8 {
+	r, _ := getUint8("", i)
+	return r
+}
+
+// Set an Uint8Item with the provided key and uint8 value.
+func (v *Vector) SetUint8(k string, vi uint8) {
+	ni := NewUint8Item(k, vi)
+	v.Set(ni)
+}
+
+// Return an uint16 from a key matching a stored Uint16Item.
+func (v *Vector) ToUint16(k string) uint16 {
+	return toUint16(v.Get(k))
+}
+
+func toUint16(i Item) uint16 {
+	r, _ := getUint16("", i)
+	return r
+}
+
+// Set an Uint16Item with the provided key and uint16 value.
+func (v *Vector) SetUint16(k string, vi uint16) {
+	ni := NewUint16Item(k, vi)
+	v.Set(ni)
+}
+
+// Return an uint32 from a key matching a stored Uint32Item.
+func (v *Vector) ToUint32(k string) uint32 {
+	return toUint32(v.Get(k))
+}
+
+func toUint32(i Item) uint32 {
+	r, _ := getUint32("", i)
+	return r
+}
+
+// Set an Uint32Item with the provided key and uint32 value.
+func (v *Vector) SetUint32(k string, vi uint32) {
+	ni := NewUint32Item(k, vi)
+	v.Set(ni)
+}
+
+// Return a float32 from a key matching a stored Float32Item.
+func (v *Vector) ToFloat32(k string) float32 {
+	return toFloat32(v.Get(k))
+}
+
+func toFloat32(i Item) float32 {
+	r, _ := getFloat32("", i)
+	return r
+}
+
+// Set a Float32Item with the provided key and float32 value.
+func (v *Vector) SetFloat32(k string, vi float32) {
+	ni := NewFloat32Item(k, vi)
+	v.Set(ni)
+}
+
+// Return a *big.Int from a key matching a stored BigIntItem.
+func (v *Vector) ToBigInt(k string) *big.Int {
+	return toBigInt(v.Get(k))
+}
+
+func toBigInt(i Item) *big.Int {
+	r, _ := getBigInt("", i)
+	return r
+}
+
+// Set a BigIntItem with the provided key and *big.Int value.
+func (v *Vector) SetBigInt(k string, vi *big.Int) {
+	ni := NewBigIntItem(k, vi)
+	v.Set(ni)
+}
+
+// Return a *big.Float from a key matching a stored BigFloatItem.
+func (v *Vector) ToBigFloat(k string) *big.Float {
+	return toBigFloat(v.Get(k))
+}
+
+func toBigFloat(i Item) *big.Float {
+	r, _ := getBigFloat("", i)
+	return r
+}
+
+// Set a BigFloatItem with the provided key and *big.Float value.
+func (v *Vector) SetBigFloat(k string, vi *big.Float) {
+	ni := NewBigFloatItem(k, vi)
+	v.Set(ni)
+}
+
+// Return a *big.Rat from a key matching a stored DecimalItem.
+func (v *Vector) ToDecimal(k string) *big.Rat {
+	return toDecimal(v.Get(k))
+}
+
+func toDecimal(i Item) *big.Rat {
+	r, _ := getDecimal("", i)
+	return r
+}
+
+// Set a DecimalItem with the provided key and *big.Rat value.
+func (v *Vector) SetDecimal(k string, vi *big.Rat) {
+	ni := NewDecimalItem(k, vi)
+	v.Set(ni)
+}
+
+// Return an array of integers from a key matching a stored IntsItem.
+func (v *Vector) ToInts(k string) []int {
+	return toInts(v.Get(k))
+}
+
+func toInts(i Item) []int {
+	r, _ := getInts("", i)
+	return r
+}
+
+// Set an IntsItem with the provided key and int values.
+func (v *Vector) SetInts(k string, vi ...int) {
+	ni := NewIntsItem(k, vi...)
+	v.Set(ni)
+}
+
+// Append the provided int values to an IntsItem with the provided key,
+// setting one where none is held.
+func (v *Vector) AppendInts(k string, vi ...int) {
+	appendTo(v, k, toInts, NewIntsItem, vi)
+}
+
+// Return an array of float64 from a key matching a stored Float64sItem.
+func (v *Vector) ToFloat64s(k string) []float64 {
+	return toFloat64s(v.Get(k))
+}
+
+func toFloat64s(i Item) []float64 {
+	r, _ := getFloat64s("", i)
+	return r
+}
+
+// Set a Float64sItem with the provided key and float64 values.
+func (v *Vector) SetFloat64s(k string, vi ...float64) {
+	ni := NewFloat64sItem(k, vi...)
+	v.Set(ni)
+}
+
+// Append the provided float64 values to a Float64sItem with the provided key,
+// setting one where none is held.
+func (v *Vector) AppendFloat64s(k string, vi ...float64) {
+	appendTo(v, k, toFloat64s, NewFloat64sItem, vi)
+}
+
+// Return an array of booleans from a key matching a stored BoolsItem.
+func (v *Vector) ToBools(k string) []bool {
+	return toBools(v.Get(k))
+}
+
+func toBools(i Item) []bool {
+	r, _ := getBools("", i)
+	return r
+}
+
+// Set a BoolsItem with the provided key and bool values.
+func (v *Vector) SetBools(k string, vi ...bool) {
+	ni := NewBoolsItem(k, vi...)
+	v.Set(ni)
+}
+
+// Append the provided bool values to a BoolsItem with the provided key,
+// setting one where none is held.
+func (v *Vector) AppendBools(k string, vi ...bool) {
+	appendTo(v, k, toBools, NewBoolsItem, vi)
+}
+
+// Return an array of any values from a key matching a stored ListItem.
+func (v *Vector) ToList(k string) []interface{} {
+	return toList(v.Get(k))
+}
+
+func toList(i Item) []interface{} {
+	r, _ := getList("", i)
+	return r
+}
+
+// Set a ListItem with the provided key and interface{} values.
+func (v *Vector) SetList(k string, vi ...interface{}) {
+	ni := NewListItem(k, vi...)
+	v.Set(ni)
+}
+
+// Append the provided interface{} values to a ListItem with the provided key,
+// setting one where none is held.
+func (v *Vector) AppendList(k string, vi ...interface{}) {
+	appendTo(v, k, toList, NewListItem, vi)
+}
+
+// Append the provided string values to a StringsItem with the provided key,
+// setting one where none is held.
+func (v *Vector) AppendStrings(k string, vi ...string) {
+	appendTo(v, k, toStrings, NewStringsItem, vi)
+}
+
+func appendTo[T any, I Item](v *Vector, k string, to func(Item) []T, fn func(string, ...T) I, vi []T) {
+	v.l.Lock()
+	defer v.l.Unlock()
+	var l []T
+	if i := v.get(Prefix(k)); i != nil && !v.expired(i) {
+		l = to(i)
+	}
+	v.setItems(OpSet, fn(k, append(append([]T{}, l...), vi...)...))
+}
+
+// Return the value of type T from a key matching a stored TypedItem[T], or an
+// error.
+func GetAs[T any](v *Vector, k string) (T, error) {
+	i := v.Get(k)
+	if ti, ok := i.(TypedItem[T]); ok {
+		return ti.Get(), nil
+	}
+	var r T
+	if i != nil {
+		if p, ok := i.Provided().(T); ok {
+			return p, nil
+		}
+	}
+	return r, check(k, i, reflect.TypeOf(&r).Elem().String())
+}
+
+// Set a TypedItem[T] with the provided key and value. See NewItem.
+func SetAs[T any](v *Vector, k string, vi T) {
+	v.Set(NewItem(k, vi))
+}
@@ -1,6 +1,9 @@
 package data
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestContainer(t *testing.T) {
 	c1 := base
@@ -59,3 +62,73 @@ func TestContainer(t *testing.T) {
 		t.Errorf("cleared keys length should be zero but was not: existing keys %v", ks)
 	}
 }
+
+func TestVectorDelete(t *testing.T) {
+	v := base.Clone()
+	v.Blacklist("always.2")
+
+	if n := v.Delete("a.int", "a.in", "not.a.key"); n != 1 {
+		t.Errorf("expected 1 item deleted, deleted %d", n)
+	}
+	if i := v.Get("a.int"); i != nil {
+		t.Errorf("expected nil item after delete, received %v", i)
+	}
+
+	if n := v.DeleteSubtree("always"); n != 1 {
+		t.Errorf("expected 1 item deleted from subtree, deleted %d", n)
+	}
+	if i := v.Get("always.2"); i == nil {
+		t.Error("blacklisted item was deleted")
+	}
+
+	n := v.DeleteFunc(func(i Item) bool {
+		return true
+	})
+	if n == 0 {
+		t.Error("expected items deleted by function")
+	}
+	if tag := v.Tag(); tag != base.Tag() {
+		t.Errorf("vector tag was deleted: %q", tag)
+	}
+
+	if n := v.Delete("vector.tag"); n != 1 {
+		t.Errorf("expected explicitly requested vector.tag deleted, deleted %d", n)
+	}
+}
+
+func TestVectorIndex(t *testing.T) {
+	v := New("INDEX")
+	v.SetStrings("hosts", "a", "b", "c")
+	v.SetList("mixed", 1, "two", []interface{}{"x", "y"})
+
+	if s := v.ToString("hosts[2]"); s != "c" {
+		t.Errorf("hosts[2] = %q", s)
+	}
+	if i := v.Get("hosts[2]"); i == nil || i.Key() != "hosts[2]" {
+		t.Errorf("indexed item keyed as %v", i)
+	}
+	if n := v.ToInt("mixed[0]"); n != 1 {
+		t.Errorf("mixed[0] = %d", n)
+	}
+	if s := v.ToString("mixed[2][1]"); s != "y" {
+		t.Errorf("mixed[2][1] = %q", s)
+	}
+	for _, k := range []string{"hosts[3]", "hosts[-1]", "hosts[x]", "none[0]"} {
+		if i := v.Get(k); i != nil {
+			t.Errorf("expected nil for %s, received %v", k, i)
+		}
+	}
+	if s := v.Snapshot().ToString("hosts[0]"); s != "a" {
+		t.Errorf("snapshot hosts[0] = %q", s)
+	}
+
+	v.AppendStrings("hosts", "d")
+	v.AppendInts("ports", 80)
+	v.AppendInts("ports", 443)
+	if l := v.ToStrings("hosts"); !reflect.DeepEqual(l, []string{"a", "b", "c", "d"}) {
+		t.Errorf("appended strings %v", l)
+	}
+	if l := v.ToInts("ports"); !reflect.DeepEqual(l, []int{80, 443}) {
+		t.Errorf("appended ints %v", l)
+	}
+}
@@ -0,0 +1,120 @@
+package data
+
+import (
+	"strings"
+	"sync"
+)
+
+// The kind of change reported by an Event.
+type Op int
+
+const (
+	OpSet Op = iota
+	OpDelete
+	OpReset
+	OpMerge
+	OpExpire
+)
+
+var opStrings = map[Op]string{
+	OpSet:    "set",
+	OpDelete: "delete",
+	OpReset:  "reset",
+	OpMerge:  "merge",
+	OpExpire: "expire",
+}
+
+//
+func (o Op) String() string {
+	if s, ok := opStrings[o]; ok {
+		return s
+	}
+	return "unknown"
+}
+
+// A change to a single Vector key. Old is nil when the key was not previously
+// set, New is nil when the key was deleted, cleared, reset, or expired.
+type Event struct {
+	Key string
+	Old Item
+	New Item
+	Op  Op
+}
+
+// The default number of Event buffered for each watcher.
+const DefaultWatchBuffer = 64
+
+type watcher struct {
+	prefix string
+	c      chan Event
+}
+
+type watchers struct {
+	l sync.Mutex
+	w map[*watcher]struct{}
+}
+
+func newWatchers() *watchers {
+	return &watchers{w: make(map[*watcher]struct{})}
+}
+
+func (ws *watchers) add(prefix string, n int) (<-chan Event, func()) {
+	w := &watcher{prefix, make(chan Event, n)}
+	ws.l.Lock()
+	ws.w[w] = struct{}{}
+	ws.l.Unlock()
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			ws.l.Lock()
+			delete(ws.w, w)
+			close(w.c)
+			ws.l.Unlock()
+		})
+	}
+	return w.c, cancel
+}
+
+// Sends the event to every watcher with a matching prefix. A watcher that has
+// not drained its buffer misses the event rather than block the sender.
+func (ws *watchers) emit(e Event) {
+	ws.l.Lock()
+	for w := range ws.w {
+		if strings.HasPrefix(e.Key, w.prefix) {
+			select {
+			case w.c <- e:
+			default:
+			}
+		}
+	}
+	ws.l.Unlock()
+}
+
+func (v *Vector) watchSet() {
+	if v.w == nil {
+		v.w = newWatchers()
+	}
+}
+
+func (v *Vector) emit(o Op, k string, old, nw Item) {
+	if v.w == nil {
+		return
+	}
+	v.w.emit(Event{k, old, nw, o})
+}
+
+// Returns a channel receiving an Event for every change to a key beginning
+// with the provided prefix, and a function that stops the watch and closes the
+// channel. Up to DefaultWatchBuffer Event are buffered; further Event are
+// dropped until the receiver catches up.
+func (v *Vector) Watch(prefix string) (<-chan Event, func()) {
+	return v.WatchBuffered(prefix, DefaultWatchBuffer)
+}
+
+// Watch with a buffer of n Event.
+func (v *Vector) WatchBuffered(prefix string, n int) (<-chan Event, func()) {
+	v.l.Lock()
+	v.watchSet()
+	v.l.Unlock()
+	return v.w.add(prefix, n)
+}
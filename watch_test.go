@@ -0,0 +1,68 @@
+package data
+
+import "testing"
+
+func receive(t *testing.T, c <-chan Event, k string, o Op) Event {
+	select {
+	case e := <-c:
+		if e.Key != k || e.Op != o {
+			t.Errorf("expected %s event for %s, received %s event for %s", o, k, e.Op, e.Key)
+		}
+		return e
+	default:
+		t.Errorf("expected %s event for %s, received none", o, k)
+	}
+	return Event{}
+}
+
+func TestWatch(t *testing.T) {
+	v := New("WATCH")
+	c, cancel := v.Watch("a.")
+
+	v.SetString("a.string", "one")
+	e := receive(t, c, "a.string", OpSet)
+	if e.Old != nil || e.New == nil {
+		t.Errorf("unexpected items in set event: %v", e)
+	}
+
+	v.SetString("a.string", "two")
+	e = receive(t, c, "a.string", OpSet)
+	if e.Old == nil || e.Old.(StringItem).ToString() != "one" {
+		t.Errorf("unexpected old item in set event: %v", e.Old)
+	}
+
+	v.SetString("b.string", "unwatched")
+	m := New("MERGE")
+	m.SetInt("a.int", 1)
+	v.Merge(m)
+	receive(t, c, "a.int", OpMerge)
+
+	v.Delete("a.int")
+	receive(t, c, "a.int", OpDelete)
+
+	v.Reset()
+	receive(t, c, "a.string", OpReset)
+	if tag := v.Tag(); tag != "WATCH" {
+		t.Errorf("vector tag not retained after reset: %q", tag)
+	}
+
+	cancel()
+	cancel()
+	if _, open := <-c; open {
+		t.Error("watch channel open after cancel")
+	}
+}
+
+func TestWatchDrop(t *testing.T) {
+	v := New("DROP")
+	c, cancel := v.WatchBuffered("", 1)
+	defer cancel()
+	v.SetString("one", "1")
+	v.SetString("two", "2")
+	receive(t, c, "one", OpSet)
+	select {
+	case e := <-c:
+		t.Errorf("expected dropped event, received %v", e)
+	default:
+	}
+}